@@ -0,0 +1,421 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	mode "wfmon/pkg"
+	"wfmon/pkg/ds"
+	"wfmon/pkg/export"
+	"wfmon/pkg/filter"
+	"wfmon/pkg/headless"
+	log "wfmon/pkg/logger"
+	"wfmon/pkg/manuf"
+	"wfmon/pkg/regulatory"
+	"wfmon/pkg/wifi"
+)
+
+// Subcommands.
+const (
+	cmdMonitor = "monitor"
+	cmdReplay  = "replay"
+	cmdExport  = "export"
+	cmdManuf   = "manuf"
+	cmdFilter  = "filter"
+	cmdHelp    = "help"
+)
+
+// Actions of filter command.
+const (
+	filterList   = "list"
+	filterSave   = "save"
+	filterDelete = "delete"
+)
+
+const (
+	exportToStdout = "-"
+)
+
+const usage = `Usage: wfmon [command] [flags]
+
+Commands:
+  monitor   monitor networks around on wireless interface (default)
+  replay    replay networks from pcap file
+  export    export networks discovered in pcap file to JSON lines, CSV or Kismet netxml and exit
+  manuf     print vendors of given MAC addresses
+  filter    list, save or delete named network filters
+
+Run 'wfmon <command> -h' for command flags.
+Environment variables are used as defaults of flags.
+
+Network filter expressions compare fields with literals and combine them with && || ! and ( ),
+e.g. -where 'band == 5 && rssi > -70 && width >= 80 && manuf ~ "Cisco"'.
+Operators: == != < <= > >= ~ (regexp match) !~. Saved filters are referenced as @name.
+`
+
+// Parses subcommand and its flags, flags override environment variables loaded before.
+func (app *Application) parseArgs(args []string) error {
+	app.cmd = cmdMonitor
+	if app.isFromFile() {
+		app.cmd = cmdReplay
+	}
+
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		app.cmd, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("wfmon "+app.cmd, flag.ContinueOnError)
+
+	switch app.cmd {
+	case cmdMonitor:
+		// explicit monitor command ignores PCAP_FILE
+		app.file = ""
+		app.monitorFlags(fs)
+		app.captureFlags(fs)
+		app.recordFlags(fs)
+		app.headlessFlags(fs)
+		app.whereFlags(fs)
+		app.smoothingFlags(fs)
+		app.ttlFlags(fs)
+		app.regDomainFlags(fs)
+		app.metricsFlags(fs)
+		app.exportFileFlags(fs)
+		app.logFlags(fs)
+	case cmdReplay:
+		app.fileFlags(fs)
+		app.captureFlags(fs)
+		app.replayFlags(fs)
+		app.headlessFlags(fs)
+		app.whereFlags(fs)
+		app.smoothingFlags(fs)
+		app.ttlFlags(fs)
+		app.regDomainFlags(fs)
+		app.metricsFlags(fs)
+		app.exportFileFlags(fs)
+		app.logFlags(fs)
+	case cmdExport:
+		app.fileFlags(fs)
+		app.captureFlags(fs)
+		app.exportFlags(fs)
+		app.whereFlags(fs)
+		app.smoothingFlags(fs)
+		app.regDomainFlags(fs)
+		app.logFlags(fs)
+	case cmdManuf:
+		fs.Usage = func() {
+			fmt.Fprintln(fs.Output(), "Usage: wfmon manuf <MAC> [MAC...]")
+		}
+	case cmdFilter:
+		fs.StringVar(&app.filtersFile, "filters-file", app.filtersFile, "named filters file (env FILTERS_FILE)")
+		fs.Usage = func() {
+			fmt.Fprintf(fs.Output(), "Usage: wfmon filter [%s | %s <name> <expression> | %s <name>]\n",
+				filterList, filterSave, filterDelete)
+			fs.PrintDefaults()
+		}
+	case cmdHelp:
+		fmt.Fprint(os.Stderr, usage)
+		return flag.ErrHelp
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command '%s'", app.cmd)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	app.args = fs.Args()
+
+	switch {
+	case (app.cmd == cmdReplay || app.cmd == cmdExport) && !app.isFromFile():
+		return fmt.Errorf("pcap file is required for %s, use -f flag or %s", app.cmd, pcapFile)
+	case app.cmd == cmdManuf && len(app.args) == 0:
+		fs.Usage()
+		return errors.New("no MAC address provided")
+	}
+
+	if err := app.smoothing.Validate(); err != nil {
+		return err
+	}
+
+	if app.cmd == cmdManuf {
+		return nil
+	}
+
+	var err error
+	if app.regChecker, err = regulatory.NewChecker(app.regDomain); err != nil {
+		return err
+	}
+
+	return app.loadFilters()
+}
+
+// Loads named filters and compiles network filter expression.
+func (app *Application) loadFilters() error {
+	var err error
+
+	if app.namedFilters, err = filter.LoadNamed(app.filtersFile); err != nil {
+		return err
+	}
+
+	if app.netFilter, err = app.namedFilters.Compile(app.where); err != nil {
+		return fmt.Errorf("invalid network filter: %w", err)
+	}
+
+	return nil
+}
+
+func (app *Application) monitorFlags(fs *flag.FlagSet) {
+	fs.StringVar(&app.ifaceName, "interface", app.ifaceName, "wireless interface, default one if empty (env INTERFACE)")
+	fs.StringVar(&app.ifaceName, "i", app.ifaceName, "shorthand for -interface")
+	fs.Func("channels", "comma separated channels to hop, all supported if empty (env CHANNELS)", app.setChannels)
+	fs.Func("c", "shorthand for -channels", app.setChannels)
+	fs.DurationVar(&app.chHopInterval, "hop", app.chHopInterval, "channel hop interval (env CHANNEL_HOP_INTERVAL)")
+	fs.DurationVar(&app.gsTimeout, "gs-timeout", app.gsTimeout, "graceful shutdown timeout (env GRACEFUL_SHUTDOWN_TIMEOUT)")
+}
+
+func (app *Application) fileFlags(fs *flag.FlagSet) {
+	fs.StringVar(&app.file, "file", app.file, "pcap or pcapng file (env PCAP_FILE)")
+	fs.StringVar(&app.file, "f", app.file, "shorthand for -file")
+}
+
+func (app *Application) captureFlags(fs *flag.FlagSet) {
+	fs.StringVar(&app.filter, "filter", app.filter,
+		fmt.Sprintf("BPF filter, management frames if empty, '%s' for all packets (env BPF_FILTER)", wifi.NoFilter))
+}
+
+func (app *Application) replayFlags(fs *flag.FlagSet) {
+	fs.Func("speed", fmt.Sprintf("replay speed, e.g. 0.5, 1, 10 or max (env REPLAY_SPEED, default %s)", app.replaySpeed),
+		func(s string) error {
+			var err error
+			app.replaySpeed, err = wifi.ParseReplaySpeed(s)
+			return err
+		})
+	fs.DurationVar(&app.gsTimeout, "gs-timeout", app.gsTimeout, "graceful shutdown timeout (env GRACEFUL_SHUTDOWN_TIMEOUT)")
+}
+
+func (app *Application) recordFlags(fs *flag.FlagSet) {
+	fs.StringVar(&app.record.Path, "record", app.record.Path, "record packets to pcapng files with given path prefix (env RECORD_FILE)")
+	fs.Int64Var(&app.record.MaxSize, "record-max-size", app.record.MaxSize, "rotate record file after size in bytes (env RECORD_MAX_SIZE)")
+	fs.DurationVar(&app.record.MaxDuration, "record-max-duration", app.record.MaxDuration,
+		"rotate record file after duration (env RECORD_MAX_DURATION)")
+	fs.BoolVar(&app.recordMgmtOnly, "record-mgmt-only", app.recordMgmtOnly, "record only management frames (env RECORD_MGMT_ONLY)")
+}
+
+func (app *Application) headlessFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&app.headless, "headless", app.headless, "stream JSON lines to stdout instead of dashboard (env HEADLESS)")
+	fs.DurationVar(&app.headlessInterval, "interval", app.headlessInterval, "headless output interval (env HEADLESS_INTERVAL)")
+	fs.Func("output", fmt.Sprintf("headless output: %s or %s (env HEADLESS_OUTPUT, default %s)",
+		headless.OutputSnapshot, headless.OutputFrames, app.headlessOutput),
+		func(s string) error {
+			var err error
+			app.headlessOutput, err = headless.ParseOutput(s)
+			return err
+		})
+}
+
+func (app *Application) exportFlags(fs *flag.FlagSet) {
+	fs.Func("format", app.exportFormatUsage(), app.setExportFormat)
+	fs.StringVar(&app.exportOutput, "output", app.exportOutput,
+		fmt.Sprintf("export file, '%s' for stdout (env EXPORT_OUTPUT)", exportToStdout))
+	fs.StringVar(&app.exportOutput, "o", app.exportOutput, "shorthand for -output")
+}
+
+// Flags of exports by dashboard hotkey.
+func (app *Application) exportFileFlags(fs *flag.FlagSet) {
+	fs.Func("export-format", app.exportFormatUsage(), app.setExportFormat)
+	fs.StringVar(&app.exportDir, "export-dir", app.exportDir, "directory of files exported by hotkey (env EXPORT_DIR)")
+}
+
+func (app *Application) exportFormatUsage() string {
+	return fmt.Sprintf("export format: %s, %s or %s (env EXPORT_FORMAT, default %s)",
+		export.FormatJSON, export.FormatCSV, export.FormatNetXML, app.exportFormat)
+}
+
+func (app *Application) setExportFormat(s string) error {
+	var err error
+	app.exportFormat, err = export.ParseFormat(s)
+	return err
+}
+
+func (app *Application) whereFlags(fs *flag.FlagSet) {
+	fs.StringVar(&app.where, "where", app.where,
+		"network filter expression or @name of saved one, e.g. 'band == 5 && rssi > -70' (env NETWORK_FILTER)")
+	fs.StringVar(&app.filtersFile, "filters-file", app.filtersFile, "named filters file (env FILTERS_FILE)")
+}
+
+func (app *Application) smoothingFlags(fs *flag.FlagSet) {
+	fs.Func("smoothing", fmt.Sprintf("smoothing of RSSI and noise: %s, %s, %s or %s (env SMOOTHING, default %s)",
+		ds.SmoothingLast, ds.SmoothingEWMA, ds.SmoothingMean, ds.SmoothingMedian, app.smoothing.Mode),
+		func(s string) error {
+			var err error
+			app.smoothing.Mode, err = ds.ParseSmoothingMode(s)
+			return err
+		})
+	fs.Float64Var(&app.smoothing.Alpha, "smoothing-alpha", app.smoothing.Alpha,
+		"weight of new value in ewma smoothing (env SMOOTHING_ALPHA)")
+	fs.IntVar(&app.smoothing.Window, "smoothing-window", app.smoothing.Window,
+		"number of values in mean and median smoothing (env SMOOTHING_WINDOW)")
+}
+
+func (app *Application) ttlFlags(fs *flag.FlagSet) {
+	fs.DurationVar(&app.ttl, "ttl", app.ttl, "remove networks not seen for given duration, 0 keeps all (env NETWORK_TTL)")
+}
+
+func (app *Application) regDomainFlags(fs *flag.FlagSet) {
+	fs.StringVar(&app.regDomain, "regdomain", app.regDomain,
+		"country code of regulatory domain to check networks against, advertised ones if empty (env REGDOMAIN)")
+}
+
+func (app *Application) metricsFlags(fs *flag.FlagSet) {
+	fs.StringVar(&app.metricsAddr, "metrics", app.metricsAddr,
+		"serve Prometheus metrics on given address, e.g. :9100, disabled if empty (env METRICS_ADDR)")
+}
+
+func (app *Application) logFlags(fs *flag.FlagSet) {
+	fs.Func("log-level", fmt.Sprintf("log level: debug, info, warn or error (env LOG_LEVEL, default %s)", app.logLevel),
+		func(s string) error {
+			var err error
+			app.logLevel, err = log.ParseLevel(s)
+			return err
+		})
+	fs.StringVar(&app.logFile, "log-file", app.logFile, "log file path (env LOG_FILE)")
+	fs.BoolFunc("v", "verbose, shorthand for -log-level=debug", func(string) error {
+		app.logLevel = log.DebugLevel
+		return nil
+	})
+	fs.Func("mode", fmt.Sprintf("application mode: dev or prod (env MODE, default %s)", app.mode), func(s string) error {
+		app.mode = mode.FromString(s)
+		return nil
+	})
+}
+
+// Waits until all frames of the file are processed and writes discovered networks.
+func (app *Application) export(ctx context.Context) error {
+	select {
+	case <-app.dataSource.Done():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	networks := app.netFilter.Apply(app.dataSource.Networks())
+
+	if app.exportOutput != exportToStdout {
+		if err := export.WriteFile(app.exportOutput, app.exportFormat, networks, app.dataSource); err != nil {
+			return err
+		}
+	} else if err := export.Write(os.Stdout, app.exportFormat, networks, app.dataSource); err != nil {
+		return fmt.Errorf("error while exporting networks: %w", err)
+	}
+
+	log.Infof("exported %d networks", len(networks))
+	return nil
+}
+
+// Prints vendors of MAC addresses given as arguments.
+func (app *Application) printManuf() {
+	for _, mac := range app.args {
+		short, long := manuf.Lookup(mac)
+		fmt.Printf("%s\t%s\t%s\n", mac, short, long)
+	}
+}
+
+// Lists, saves or deletes named filters.
+func (app *Application) runFilter() error {
+	action := filterList
+	args := app.args
+	if len(args) > 0 {
+		action, args = args[0], args[1:]
+	}
+
+	switch {
+	case action == filterList && len(args) == 0:
+		names := make([]string, 0, len(app.namedFilters))
+		for name := range app.namedFilters {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		for _, name := range names {
+			fmt.Printf("%s%s\t%s\n", filter.NamedPrefix, name, app.namedFilters[name])
+		}
+		return nil
+
+	case action == filterSave && len(args) == 2: //nolint:gomnd // ignore
+		if err := app.namedFilters.Set(args[0], args[1]); err != nil {
+			return err
+		}
+		return app.namedFilters.Save(app.filtersFile)
+
+	case action == filterDelete && len(args) == 1:
+		if _, found := app.namedFilters[args[0]]; !found {
+			return fmt.Errorf("named filter '%s' not found", args[0])
+		}
+		delete(app.namedFilters, args[0])
+		return app.namedFilters.Save(app.filtersFile)
+
+	default:
+		return fmt.Errorf("invalid filter command, see 'wfmon filter -h'")
+	}
+}
+
+// Parses comma separated channels list.
+func (app *Application) setChannels(s string) error {
+	var err error
+	app.channels, err = parseChannels(s)
+	return err
+}
+
+// Returns channels parsed from comma separated list.
+func parseChannels(s string) ([]int, error) {
+	var channels []int
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); len(item) == 0 {
+			continue
+		}
+
+		channel, err := strconv.Atoi(item)
+		if err != nil || channel <= 0 {
+			return nil, fmt.Errorf("invalid channel '%s'", item)
+		}
+		channels = append(channels, channel)
+	}
+
+	return channels, nil
+}
+
+// Returns environment variable value or default one.
+func envOr(key, def string) string {
+	if val, ok := os.LookupEnv(key); ok {
+		return val
+	}
+	return def
+}
+
+// Sets value parsed from environment variable, keeps default one if variable is empty.
+func envParse[T any](key string, val *T, parse func(string) (T, error)) error {
+	s := os.Getenv(key)
+	if len(s) == 0 {
+		return nil
+	}
+
+	parsed, err := parse(s)
+	if err != nil {
+		return fmt.Errorf("invalid %s '%s': %w", key, s, err)
+	}
+	*val = parsed
+
+	return nil
+}
+
+func parseInt64(s string) (int64, error) {
+	return strconv.ParseInt(s, 10, 64)
+}
+
+func parseFloat64(s string) (float64, error) {
+	return strconv.ParseFloat(s, 64)
+}
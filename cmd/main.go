@@ -3,8 +3,12 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
+	"fmt"
 	"net"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
@@ -12,12 +16,20 @@ import (
 	mode "wfmon/pkg"
 	netdata "wfmon/pkg/data/net"
 	"wfmon/pkg/ds"
+	"wfmon/pkg/export"
+	"wfmon/pkg/filter"
+	"wfmon/pkg/headless"
 	log "wfmon/pkg/logger"
+	"wfmon/pkg/metrics"
 	"wfmon/pkg/network"
 	radionet "wfmon/pkg/network/radio"
 	"wfmon/pkg/radio"
+	"wfmon/pkg/regulatory"
 	"wfmon/pkg/serv"
+	"wfmon/pkg/utils/cmp"
+	"wfmon/pkg/widgets/clients"
 	"wfmon/pkg/widgets/dashboard"
+	"wfmon/pkg/widgets/info"
 	"wfmon/pkg/widgets/sparkline"
 	"wfmon/pkg/widgets/spectrum"
 	"wfmon/pkg/widgets/wifitable"
@@ -25,12 +37,20 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 const (
 	envMode          = "MODE"
 	pcapFile         = "PCAP_FILE"
+	replaySpeed      = "REPLAY_SPEED"
+	recordFile       = "RECORD_FILE"
+	bpfFilter        = "BPF_FILTER"
+	envHeadless      = "HEADLESS"
 	defaultGSTimeout = time.Second * 15
+	defaultTTL       = time.Minute * 10
+	exitFailure      = 1
+	exitUsage        = 2
 )
 
 type Application struct {
@@ -41,37 +61,107 @@ type Application struct {
 	shutdowners []serv.Shutdowner
 	program     *tea.Program
 
+	cmd               string
+	args              []string
 	mode              mode.Mode
+	logLevel          zapcore.Level
+	logFile           string
 	gsTimeout         time.Duration
 	chHopInterval     time.Duration
+	channels          []int
 	ifaceName         string
 	iface             *net.Interface
 	file              string
+	replaySpeed       wifi.ReplaySpeed
+	filter            string
+	headless          bool
+	headlessInterval  time.Duration
+	headlessOutput    headless.Output
+	record            network.RecorderConfig
+	recordMgmtOnly    bool
+	exportFormat      export.Format
+	exportOutput      string
+	exportDir         string
+	where             string
+	filtersFile       string
+	namedFilters      filter.Named
+	netFilter         *filter.Filter
+	smoothing         ds.Smoothing
+	ttl               time.Duration
+	regDomain         string
+	regChecker        *regulatory.Checker
+	metricsAddr       string
+	dataSource        *ds.DataSource
 	associatedNetwork network.Network
 }
 
-func loadApplication() *Application {
+// Loads application configuration from environment variables and command line arguments.
+func loadApplication(args []string) (*Application, error) {
 	var err error
 
 	app := &Application{}
 	app.mode = mode.FromString(os.Getenv(envMode))
 	app.file = os.Getenv(pcapFile)
+	app.ifaceName = os.Getenv("INTERFACE")
+	app.gsTimeout = defaultGSTimeout
+	app.chHopInterval = radio.DefaultHopInterval
+	app.replaySpeed = wifi.DefaultReplaySpeed
+	app.filter = os.Getenv(bpfFilter)
+	app.headlessInterval = headless.DefaultInterval
+	app.headlessOutput = headless.OutputSnapshot
+	app.record.Path = os.Getenv(recordFile)
+	app.exportFormat = export.FormatJSON
+	app.exportOutput = envOr("EXPORT_OUTPUT", exportToStdout)
+	app.exportDir = os.Getenv("EXPORT_DIR")
+	app.where = os.Getenv("NETWORK_FILTER")
+	app.filtersFile = envOr("FILTERS_FILE", filter.DefaultNamedPath())
+	app.smoothing = ds.DefaultSmoothing()
+	app.ttl = defaultTTL
+	app.regDomain = os.Getenv("REGDOMAIN")
+	app.metricsAddr = os.Getenv("METRICS_ADDR")
+	app.logFile = envOr("LOG_FILE", log.DefaultFilename)
+	app.logLevel = cmp.Nvl(app.mode == mode.Dev, log.DebugLevel, log.DefaultLevel)
+
+	// invalid values are rejected as well as invalid flags, empty ones keep defaults
+	if err = errors.Join(
+		envParse("GRACEFUL_SHUTDOWN_TIMEOUT", &app.gsTimeout, time.ParseDuration),
+		envParse("CHANNEL_HOP_INTERVAL", &app.chHopInterval, time.ParseDuration),
+		envParse("CHANNELS", &app.channels, parseChannels),
+		envParse(replaySpeed, &app.replaySpeed, wifi.ParseReplaySpeed),
+		envParse(envHeadless, &app.headless, strconv.ParseBool),
+		envParse("HEADLESS_INTERVAL", &app.headlessInterval, time.ParseDuration),
+		envParse("HEADLESS_OUTPUT", &app.headlessOutput, headless.ParseOutput),
+		envParse("RECORD_MAX_SIZE", &app.record.MaxSize, parseInt64),
+		envParse("RECORD_MAX_DURATION", &app.record.MaxDuration, time.ParseDuration),
+		envParse("RECORD_MGMT_ONLY", &app.recordMgmtOnly, strconv.ParseBool),
+		envParse("EXPORT_FORMAT", &app.exportFormat, export.ParseFormat),
+		envParse("SMOOTHING", &app.smoothing.Mode, ds.ParseSmoothingMode),
+		envParse("SMOOTHING_ALPHA", &app.smoothing.Alpha, parseFloat64),
+		envParse("SMOOTHING_WINDOW", &app.smoothing.Window, strconv.Atoi),
+		envParse("NETWORK_TTL", &app.ttl, time.ParseDuration),
+		envParse("LOG_LEVEL", &app.logLevel, log.ParseLevel),
+	); err != nil {
+		return nil, err
+	}
 
-	if !app.isFromFile() {
+	if err = app.parseArgs(args); err != nil {
+		return nil, err
+	}
+
+	// find default interface for live capture
+	if app.cmd == cmdMonitor && !app.isFromFile() && len(app.ifaceName) == 0 {
 		if app.ifaceName, err = radionet.GetDefaultWiFiInterface(); err != nil {
 			app.ifaceName = strings.TrimSpace("en0")
 		}
 	}
 
-	if app.gsTimeout, err = time.ParseDuration(os.Getenv("GRACEFUL_SHUTDOWN_TIMEOUT")); err != nil {
-		app.gsTimeout = defaultGSTimeout
-	}
-
-	if app.chHopInterval, err = time.ParseDuration(os.Getenv("CHANNEL_HOP_INTERVAL")); err != nil {
-		app.chHopInterval = radio.DefaultHopInterval
+	// export reads whole file as fast as possible and keeps all networks
+	if app.cmd == cmdExport {
+		app.replaySpeed = wifi.ReplaySpeedMax
+		app.ttl = 0
 	}
 
-	return app
+	return app, nil
 }
 
 func (app *Application) isFromFile() bool {
@@ -79,7 +169,10 @@ func (app *Application) isFromFile() bool {
 }
 
 func (app *Application) initLogger() {
-	logger := log.NewLogger(app.mode)
+	logger := log.NewLogger(app.mode,
+		log.WithLevel(app.logLevel),
+		log.WithFilename(app.logFile),
+	)
 
 	app.log = logger.Sugar()
 
@@ -168,43 +261,46 @@ func (app *Application) init(ctx context.Context) {
 
 	// create wifi monitor
 	mon := wifi.NewMonitor(&wifi.Config{
-		IFace: app.iface,
-		File:  app.file,
+		IFace:       app.iface,
+		File:        app.file,
+		ReplaySpeed: app.replaySpeed,
+		Filter:      app.filter,
+
+		Record:         app.record,
+		RecordMgmtOnly: app.recordMgmtOnly,
 	})
 
-	// create datasource and tui
-	dataSource := ds.New(mon.GetFrames())
-	dashboard := dashboard.New(
-		dashboard.WithTable(wifitable.New(
-			wifitable.WithFocused(true),
-			wifitable.WithAssociated(netdata.NewKey(
-				app.associatedNetwork.BSSID,
-				app.associatedNetwork.SSID,
-			)),
-		)),
-		dashboard.WithSparkline(sparkline.New(
-			sparkline.WithFocused(true),
-			sparkline.WithYAxe(true),
-			sparkline.WithSignalField(wifitable.BarsFieldMsg()),
-		)),
-		dashboard.WithSpectrum(spectrum.New(
-			spectrum.WithFocused(false),
-			spectrum.WithSignalField(wifitable.BarsFieldMsg()),
-		)),
-		dashboard.WithDataSource(dataSource),
-		// dashboard.WithDataSource(ds.EmptyProvider{}),
-	)
+	// create datasource
+	dsOpts := []ds.Option{
+		ds.WithClientFrames(mon.GetClientFrames()),
+		ds.WithSmoothing(app.smoothing),
+		ds.WithTTL(app.ttl),
+		ds.WithRegulatoryChecker(app.regChecker),
+	}
+	// live networks age by wall clock, replayed ones by capture time
+	if !app.isFromFile() {
+		dsOpts = append(dsOpts, ds.WithClock(time.Now))
+	}
+	dataSource := ds.New(mon.GetFrames(), dsOpts...)
+	app.dataSource = dataSource
+
+	// configure monitor before outputs, replay controls exist only if file is opened
+	if err := mon.Configure(); err != nil {
+		log.Fatal(err)
+	}
 
 	// setup services
-	app.servs = []serv.Serv{mon}
+	app.servs = []serv.Serv{}
 	app.starters = []serv.Starter{mon, dataSource}
 	app.shutdowners = []serv.Shutdowner{mon}
 
 	// create channel hopper
+	var hopper *radio.ChannelHopperServ
 	if !app.isFromFile() {
-		hopper := radio.NewChannelHopperServ(&radio.ChannelHopperConfig{
+		hopper = radio.NewChannelHopperServ(&radio.ChannelHopperConfig{
 			IFace:       app.iface,
 			HopInterval: app.chHopInterval,
+			Channels:    app.channels,
 		})
 
 		app.servs = append(app.servs, hopper)
@@ -212,6 +308,20 @@ func (app *Application) init(ctx context.Context) {
 		app.shutdowners = append(app.shutdowners, hopper)
 	}
 
+	// create metrics endpoint, works along with any output
+	if len(app.metricsAddr) > 0 && app.cmd != cmdExport {
+		app.initMetrics(mon, hopper, dataSource)
+	}
+
+	// create json lines output or tui, export writes networks on its own
+	switch {
+	case app.cmd == cmdExport:
+	case app.headless:
+		app.initHeadless(dataSource)
+	default:
+		app.initDashboard(ctx, mon, dataSource)
+	}
+
 	// run configurations
 	for _, configer := range app.servs {
 		serv := configer
@@ -219,6 +329,80 @@ func (app *Application) init(ctx context.Context) {
 			log.Fatal(err)
 		}
 	}
+}
+
+// Creates headless service streaming network data to stdout.
+func (app *Application) initHeadless(dataSource *ds.DataSource) {
+	output := headless.New(&headless.Config{
+		Writer:   os.Stdout,
+		Interval: app.headlessInterval,
+		Output:   app.headlessOutput,
+		Filter:   app.netFilter,
+	}, dataSource)
+
+	app.servs = append(app.servs, output)
+	app.starters = append(app.starters, output)
+	app.shutdowners = append(app.shutdowners, output)
+}
+
+// Creates service exposing networks and counters in Prometheus format.
+func (app *Application) initMetrics(mon *wifi.Monitor, hopper *radio.ChannelHopperServ, dataSource *ds.DataSource) {
+	cfg := &metrics.Config{
+		Addr:    app.metricsAddr,
+		Filter:  app.netFilter,
+		Monitor: mon,
+	}
+	// avoid typed nil in interface
+	if hopper != nil {
+		cfg.Hopper = hopper
+	}
+
+	output := metrics.New(cfg, dataSource)
+
+	app.servs = append(app.servs, output)
+	app.starters = append(app.starters, output)
+	app.shutdowners = append(app.shutdowners, output)
+}
+
+// Creates tea program with dashboard.
+func (app *Application) initDashboard(ctx context.Context, mon *wifi.Monitor, dataSource *ds.DataSource) {
+	dashboardOpts := []dashboard.Option{}
+	// avoid typed nil in interface
+	if replay := mon.Replay(); replay != nil {
+		dashboardOpts = append(dashboardOpts, dashboard.WithReplay(replay))
+	}
+	dashboard := dashboard.New(append(dashboardOpts,
+		dashboard.WithTable(wifitable.New(
+			wifitable.WithFocused(true),
+			wifitable.WithNamedFilters(app.namedFilters),
+			wifitable.WithFilter(app.where),
+			// band of associated network is not reported by interface
+			wifitable.WithAssociated(netdata.NewKey(app.associatedNetwork.BSSID, "")),
+		)),
+		dashboard.WithSparkline(sparkline.New(
+			sparkline.WithFocused(true),
+			sparkline.WithYAxe(true),
+			sparkline.WithSignalField(wifitable.BarsFieldMsg()),
+			sparkline.WithAltField(wifitable.UtilFieldMsg()),
+		)),
+		dashboard.WithSpectrum(spectrum.New(
+			spectrum.WithFocused(false),
+			spectrum.WithSignalField(wifitable.BarsFieldMsg()),
+		)),
+		dashboard.WithInfo(info.New(
+			info.WithFocused(false),
+		)),
+		dashboard.WithClients(clients.New(
+			clients.WithFocused(false),
+		)),
+		dashboard.WithExporter(export.NewFileExporter(&export.FileConfig{
+			Dir:    app.exportDir,
+			Format: app.exportFormat,
+			Filter: app.netFilter,
+		}, dataSource)),
+		dashboard.WithDataSource(dataSource),
+		// dashboard.WithDataSource(ds.EmptyProvider{}),
+	)...)
 
 	// create tea program
 	app.program = tea.NewProgram(
@@ -229,8 +413,11 @@ func (app *Application) init(ctx context.Context) {
 	)
 }
 
-// Runs services in seprate goroutings and blocks main with tea program.
-func (app *Application) start(ctx context.Context) {
+// Runs services in seprate goroutings and blocks main with tea program or until a signal in headless mode.
+// Export blocks until networks are written, returns error of failed export.
+func (app *Application) start(ctx context.Context) error {
+	var err error
+
 	// run services
 	for _, starter := range app.starters {
 		go func(starter serv.Starter) {
@@ -241,24 +428,63 @@ func (app *Application) start(ctx context.Context) {
 	}
 
 	// Blocks application execution until SIGINT (Ctrl+C) and SIGTERM (Ctrl+/)
-	if _, err := app.program.Run(); err != nil {
-		log.Fatal(err)
+	switch {
+	case app.cmd == cmdExport:
+		if err = app.export(ctx); err != nil {
+			log.Error(err)
+		}
+	case app.headless:
+		sigCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		<-sigCtx.Done()
+		stop()
+	default:
+		if _, err := app.program.Run(); err != nil {
+			log.Fatal(err)
+		}
 	}
 
 	log.Info("shutting down")
 	app.shutdown()
+
+	return err
 }
 
 func main() {
-	app := loadApplication()
+	app, err := loadApplication(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitUsage)
+	}
+
+	switch app.cmd {
+	case cmdManuf:
+		app.printManuf()
+		return
+	case cmdFilter:
+		if err = app.runFilter(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(exitUsage)
+		}
+		return
+	}
 
 	app.initLogger()
-	defer app.closeLogger()
 
 	log.Info("🚀 starting")
 	log.Debugf("app mode %s", app.mode)
 
 	ctx := context.Background()
 	app.init(ctx)
-	app.start(ctx)
+	err = app.start(ctx)
+
+	app.closeLogger()
+
+	// scripts detect failed export by exit code
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitFailure)
+	}
 }
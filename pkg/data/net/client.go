@@ -0,0 +1,61 @@
+package netdata
+
+import (
+	"slices"
+	"time"
+)
+
+// Aggregated client station data.
+type Client struct {
+	MAC         string    // Client station MAC address
+	Manuf       string    // Short vendor' name
+	ManufLong   string    // Long vendor' name
+	BSSID       string    // Associated access point, empty if not associated
+	RSSI        int8      // Signal of the latest frame transmitted by client, dBm
+	ProbedSSIDs []string  // SSIDs of directed probe requests
+	Timestamp   time.Time // Capture time of the latest frame (last seen)
+	FirstSeen   time.Time // Capture time of the first frame
+	Frames      uint      // Number of frames received
+}
+
+// Returns true if client is associated with an access point.
+func (c *Client) Associated() bool {
+	return len(c.BSSID) > 0
+}
+
+// Adds SSID to probed ones if it's new.
+func (c *Client) AddProbedSSID(ssid string) {
+	if len(ssid) > 0 && !slices.Contains(c.ProbedSSIDs, ssid) {
+		c.ProbedSSIDs = append(c.ProbedSSIDs, ssid)
+	}
+}
+
+// Client data map by MAC address.
+type ClientTable map[string]*Client
+
+// Client data slice.
+type ClientSlice []Client
+
+// Returns slice of clients copied from table.
+func (t ClientTable) Slice() ClientSlice {
+	s := make(ClientSlice, 0, len(t))
+	for _, data := range t {
+		client := *data
+		client.ProbedSSIDs = slices.Clone(data.ProbedSSIDs)
+		s = append(s, client)
+	}
+
+	return s
+}
+
+// Returns number of associated clients by BSSID.
+func (t ClientTable) CountByBSSID() map[string]int {
+	counts := map[string]int{}
+	for _, data := range t {
+		if data.Associated() {
+			counts[data.BSSID]++
+		}
+	}
+
+	return counts
+}
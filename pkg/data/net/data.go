@@ -1,6 +1,7 @@
 package netdata
 
 import (
+	"time"
 	"wfmon/pkg/utils/cmp"
 	"wfmon/pkg/wifi"
 )
@@ -18,6 +19,17 @@ const (
 	BarsKey      = "Bars"
 	NoiseKey     = "Noise"
 	SNRKey       = "SNR"
+	SecurityKey  = "Security"
+	PHYKey       = "PHY"
+	SeenKey      = "Seen"
+	AgeKey       = "Age"
+	ClientsKey   = "Clients"
+	StationsKey  = "Stations"
+	UtilKey      = "Util%"
+	CountryKey   = "Country"
+	HiddenKey    = "Hidden"
+	WPSNameKey   = "WPS Name"
+	WPSModelKey  = "WPS Model"
 )
 
 // Aggragated network data.
@@ -25,39 +37,94 @@ type Network struct {
 	BSSID            string                      // Station MAC address
 	Manuf            string                      // Short vendor' name
 	ManufLong        string                      // Long vendor' name
-	NetworkName      string                      // SSID
+	NetworkName      string                      // SSID, revealed name of hidden network
+	Hidden           bool                        // SSID is hidden, i.e. empty or NUL-filled
 	Channel          uint8                       // Primary channel number
+	Frequency        int                         // Channel frequency, MHz
 	Offset           wifi.SecondaryChannelOffset // Secondary channel direction (2.5/5Ghz HT)
 	FrequencyCenter0 uint8                       // Lower frequency segment center (5GHz VHT)
 	FrequencyCenter1 uint8                       // Second frequency segment center (5GHz VHT)
 	ChannelWidth     uint16                      // Channel width, MHz
 	WidthOperation   wifi.ChannelWidthOperation  // Channel width operation (5GHz VHT)
-	Band             wifi.Band                   // Bandwidth 2.4/5, Ghz
+	Band             wifi.Band                   // Bandwidth 2.4/5/6, Ghz
+	PHY              wifi.PHY                    // Supported PHY generations (802.11b/g/n/ac/ax/be)
 	RSSI             int8                        // Received Signal Strength Indicator (RSSI), dBm
 	Quality          Quality                     // Signal Quality, %
 	Noise            int8                        // Noise level, dBm
 	SNR              int8                        // Signal to Noise Ratio (SNR), dBm
-	// Seen
+	Security         wifi.Security               // Security protocols and authentication summary
+	SecurityIE       wifi.SecurityIE             // RSN and WPA elements
+	CapabilityInfo   wifi.CapabilityInfo         // Capability information field
+	IE               wifi.InformationElements    // Information elements decoded from the latest frame
+	BSSLoad          wifi.BSSLoadIE              // Station count and channel utilization advertised by AP
+	Country          wifi.CountryIE              // Country code and channels advertised by AP
+	WPS              wifi.WPSIE                  // Wi-Fi Protected Setup state and device advertised by AP
+	RegIssues        []string                    // Violations of regulatory domain found by checker
+	Timestamp        time.Time                   // Capture time of the latest frame (last seen)
+	FirstSeen        time.Time                   // Capture time of the first frame
+	Frames           uint                        // Number of frames received
+	OffChannel       bool                        // Latest frame was received on frequency of another channel
+	OffChannelFrames uint                        // Number of frames received off the advertised channel
+	Clients          int                         // Number of associated client stations
+	SSIDs            []SSIDChange                // SSIDs observed over time, the latest last
 	// Rate
 }
 
-// Returns network data key.
+// SSID observed since capture time.
+type SSIDChange struct {
+	SSID      string    `json:"ssid"`      // empty for hidden network
+	Timestamp time.Time `json:"timestamp"` // capture time of the first frame with SSID
+}
+
+// Returns network data key: BSSID and band.
 func (data *Network) Key() Key {
-	return NewKey(data.BSSID, data.NetworkName)
+	return NewKey(data.BSSID, data.Band.Range())
 }
 
-// Network data uniq key in table.
+// Compares networks by name, hidden networks last, then by key.
 // Used for sorting in table.
+func (data *Network) Compare(other *Network) int {
+	var res int
+	switch {
+	case len(data.NetworkName) == 0 && len(other.NetworkName) == 0:
+		res = 0
+	case len(data.NetworkName) == 0:
+		res = 1
+	case len(other.NetworkName) == 0:
+		res = -1
+	default:
+		res = cmp.Compare(data.NetworkName, other.NetworkName)
+	}
+
+	if res == 0 {
+		res = data.Key().Compare(other.Key())
+	}
+
+	return res
+}
+
+// Returns true if name of hidden network was revealed by probe response or association request.
+func (data *Network) Revealed() bool {
+	return data.Hidden && len(data.NetworkName) > 0
+}
+
+// Returns true if channel is derived from frequency, as no element advertised it.
+func (data *Network) ChannelFromFrequency() bool {
+	return data.IE.Channel == 0
+}
+
+// Network data uniq key in table.
+// An AP might advertise the same BSSID in several bands.
 type Key struct {
-	BSSID       string
-	NetworkName string
+	BSSID string
+	Band  string // band range, e.g. 2.4, 5 or 6
 }
 
 // Returns new network data key.
-func NewKey(bssID, ssID string) Key {
+func NewKey(bssID, band string) Key {
 	return Key{
-		BSSID:       bssID,
-		NetworkName: ssID,
+		BSSID: bssID,
+		Band:  band,
 	}
 }
 
@@ -67,19 +134,9 @@ func Empty() Key {
 
 // Compares network data keys.
 func (key Key) Compare(other Key) int {
-	var res int
-	switch {
-	case len(key.NetworkName) == 0 && len(other.NetworkName) == 0:
-		res = cmp.Compare(key.BSSID, other.BSSID)
-	case len(key.NetworkName) == 0:
-		res = 1
-	case len(other.NetworkName) == 0:
-		res = -1
-	default:
-		res = cmp.Compare(key.NetworkName, other.NetworkName)
-		if res == 0 {
-			res = cmp.Compare(key.BSSID, other.BSSID)
-		}
+	res := cmp.Compare(key.BSSID, other.BSSID)
+	if res == 0 {
+		res = cmp.Compare(key.Band, other.Band)
 	}
 
 	return res
@@ -0,0 +1,58 @@
+package netdata
+
+import (
+	"time"
+)
+
+// Flat network data with human readable values.
+// Used for serialization, e.g. JSON lines of headless mode.
+type Record struct {
+	Timestamp time.Time `json:"timestamp"`
+	FirstSeen time.Time `json:"first_seen"`
+	Frames    uint      `json:"frames"`
+	BSSID     string    `json:"bssid"`
+	SSID      string    `json:"ssid"`
+	Hidden    bool      `json:"hidden,omitempty"`
+	Manuf     string    `json:"manuf,omitempty"`
+	Channel   uint8     `json:"channel"`
+	Width     uint16    `json:"width"`
+	Band      string    `json:"band"`
+	PHY       string    `json:"phy"`
+	Security  string    `json:"security"`
+	RSSI      int8      `json:"rssi"`
+	Noise     int8      `json:"noise"`
+	SNR       int8      `json:"snr"`
+	Quality   uint8     `json:"quality"`
+	Clients   int       `json:"clients"`
+	Country   string    `json:"country,omitempty"`
+	WPSName   string    `json:"wps_name,omitempty"`
+	WPSModel  string    `json:"wps_model,omitempty"`
+	RegIssues []string  `json:"reg_issues,omitempty"`
+}
+
+// Returns flat record of network data.
+func (data *Network) Record() Record {
+	return Record{
+		Timestamp: data.Timestamp,
+		FirstSeen: data.FirstSeen,
+		Frames:    data.Frames,
+		BSSID:     data.BSSID,
+		SSID:      data.NetworkName,
+		Hidden:    data.Hidden,
+		Manuf:     data.ManufLong,
+		Channel:   data.Channel,
+		Width:     data.ChannelWidth,
+		Band:      data.Band.String(),
+		PHY:       data.PHY.String(),
+		Security:  data.Security.String(),
+		RSSI:      data.RSSI,
+		Noise:     data.Noise,
+		SNR:       data.SNR,
+		Quality:   uint8(data.Quality),
+		Clients:   data.Clients,
+		Country:   data.Country.Country,
+		WPSName:   data.WPS.DeviceName,
+		WPSModel:  data.WPS.Model(),
+		RegIssues: data.RegIssues,
+	}
+}
@@ -1,6 +1,7 @@
 package ds
 
 import (
+	"time"
 	netdata "wfmon/pkg/data/net"
 	"wfmon/pkg/ts"
 )
@@ -13,9 +14,29 @@ type TimeSeriesProvider interface {
 	TimeSeries(netKey netdata.Key) func(colKey string) ts.TimeSeries
 }
 
+// Provides statistics of all values observed, not limited by size of time series.
+type StatsProvider interface {
+	Stats(netKey netdata.Key) func(colKey string) ts.Stats
+}
+
+type ClientProvider interface {
+	Clients() netdata.ClientSlice
+}
+
+// Provides current time to calculate age of networks.
+type Clock interface {
+	Now() time.Time
+}
+
+// Provides smoothing applied to signal values of networks.
+type SmoothingProvider interface {
+	Smoothing() Smoothing
+}
+
 type Provider interface {
 	NetworkProvider
 	TimeSeriesProvider
+	StatsProvider
 }
 
 type EmptyProvider struct {
@@ -30,3 +51,9 @@ func (ds EmptyProvider) TimeSeries(netKey netdata.Key) func(colKey string) ts.Ti
 		return ts.Empty()
 	}
 }
+
+func (ds EmptyProvider) Stats(netKey netdata.Key) func(colKey string) ts.Stats {
+	return func(colKey string) ts.Stats {
+		return ts.Stats{}
+	}
+}
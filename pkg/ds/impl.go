@@ -2,18 +2,20 @@ package ds
 
 import (
 	"context"
-	"fmt"
 	"sync"
 	"time"
 
 	netdata "wfmon/pkg/data/net"
 	"wfmon/pkg/manuf" //nolint
+	"wfmon/pkg/regulatory"
 	"wfmon/pkg/ts"
+	"wfmon/pkg/utils/conv"
 	"wfmon/pkg/wifi"
 )
 
 const (
 	defaultTimeSeriesSize = 200
+	evictInterval         = time.Second
 )
 
 // Wraps networks table.
@@ -21,37 +23,146 @@ type DataSource struct {
 	table     netdata.Table
 	tableLock sync.RWMutex
 
-	ts     map[netdata.Key]map[string]ts.TimeSeries
-	tsLock sync.RWMutex
+	clients netdata.ClientTable // guarded by tableLock
 
-	ctx      context.Context
-	stop     context.CancelFunc
-	framesCh <-chan wifi.Frame
+	ts        map[netdata.Key]map[string]ts.TimeSeries
+	summaries map[netdata.Key]map[string]ts.Summary // guarded by tsLock, aggregates of all samples
+	tsLock    sync.RWMutex
+
+	ctx       context.Context
+	stop      context.CancelFunc
+	framesCh  <-chan wifi.Frame
+	clientsCh <-chan wifi.ClientFrame
+	done      chan struct{}
+
+	observers     []func(netdata.Network)
+	observersLock sync.RWMutex
+
+	smoothing Smoothing
+	smoothers map[netdata.Key]*signalSmoother
+
+	checker *regulatory.Checker // flags networks violating regulatory domain
+
+	ttl    time.Duration    // networks not seen longer are evicted, disabled if zero
+	clock  func() time.Time // current time, capture time of the latest frame by default
+	latest time.Time        // capture time of the latest frame
+}
+
+type Option func(*DataSource)
+
+// Sets smoothing of signal values in networks table.
+func WithSmoothing(smoothing Smoothing) Option {
+	return func(ds *DataSource) {
+		ds.smoothing = smoothing
+	}
+}
+
+// Sets time to live of networks not seen, zero disables eviction.
+func WithTTL(ttl time.Duration) Option {
+	return func(ds *DataSource) {
+		ds.ttl = ttl
+	}
+}
+
+// Sets source of client frames, clients are not discovered without it.
+func WithClientFrames(clientsCh <-chan wifi.ClientFrame) Option {
+	return func(ds *DataSource) {
+		ds.clientsCh = clientsCh
+	}
+}
+
+// Sets checker of regulatory domain, advertised domains are checked by default.
+func WithRegulatoryChecker(checker *regulatory.Checker) Option {
+	return func(ds *DataSource) {
+		ds.checker = checker
+	}
+}
+
+// Sets source of current time, e.g. time.Now for live capture.
+func WithClock(clock func() time.Time) Option {
+	return func(ds *DataSource) {
+		ds.clock = clock
+	}
 }
 
 // Returns new networks table.
-func New(framesCh <-chan wifi.Frame) *DataSource {
+func New(framesCh <-chan wifi.Frame, opts ...Option) *DataSource {
 	const defaultInitTableSize = 20
 
-	return &DataSource{
-		table:    make(netdata.Table, defaultInitTableSize),
-		ts:       make(map[netdata.Key]map[string]ts.TimeSeries),
-		framesCh: framesCh,
+	ds := &DataSource{
+		table:     make(netdata.Table, defaultInitTableSize),
+		clients:   make(netdata.ClientTable),
+		ts:        make(map[netdata.Key]map[string]ts.TimeSeries),
+		summaries: make(map[netdata.Key]map[string]ts.Summary),
+		framesCh:  framesCh,
+		done:      make(chan struct{}),
+		smoothing: DefaultSmoothing(),
+		smoothers: make(map[netdata.Key]*signalSmoother),
+		checker:   &regulatory.Checker{},
 	}
+
+	for _, opt := range opts {
+		opt(ds)
+	}
+
+	return ds
+}
+
+// Returns current time to calculate age of networks.
+// Capture time of the latest frame is used unless clock is given, so replayed networks age by capture time.
+func (ds *DataSource) Now() time.Time {
+	if ds.clock != nil {
+		return ds.clock()
+	}
+
+	ds.tableLock.RLock()
+	defer ds.tableLock.RUnlock()
+
+	return ds.latest
+}
+
+// Returns smoothing applied to signal values of networks.
+func (ds *DataSource) Smoothing() Smoothing {
+	return ds.smoothing
 }
 
 // Starts processing incomming frames from packets.
 func (ds *DataSource) Start(ctx context.Context) error {
 	ds.ctx, ds.stop = context.WithCancel(ctx)
 
+	evictTicker := time.NewTicker(evictInterval)
+	defer evictTicker.Stop()
+
 	for {
 		select {
+		case <-evictTicker.C:
+			ds.Evict()
+
+		case frame, ok := <-ds.clientsCh:
+			if !ok {
+				ds.clientsCh = nil
+				continue
+			}
+			ds.AddClient(&frame)
+
 		case frame, ok := <-ds.framesCh:
+			// frames from file are over
 			if !ok {
-				return fmt.Errorf("frames source closed, stopping updating table")
+				// remaining client frames are sent before frames channel is closed
+				if ds.clientsCh != nil {
+					for frame := range ds.clientsCh {
+						ds.AddClient(&frame)
+					}
+				}
+				close(ds.done)
+				return nil
 			}
 
-			ds.Add(frameConverter(frame).Network())
+			network := frameConverter(frame).Network()
+			// observers receive raw values
+			raw := *network
+			ds.Add(network)
+			ds.notify(raw)
 
 		case <-ds.ctx.Done():
 			return nil
@@ -59,7 +170,30 @@ func (ds *DataSource) Start(ctx context.Context) error {
 	}
 }
 
+// Returns channel closed when frames source is over.
+func (ds *DataSource) Done() <-chan struct{} {
+	return ds.done
+}
+
+// Registers observer of every network observation converted from incoming frame.
+func (ds *DataSource) Subscribe(observer func(netdata.Network)) {
+	ds.observersLock.Lock()
+	defer ds.observersLock.Unlock()
+
+	ds.observers = append(ds.observers, observer)
+}
+
+func (ds *DataSource) notify(network netdata.Network) {
+	ds.observersLock.RLock()
+	defer ds.observersLock.RUnlock()
+
+	for _, observer := range ds.observers {
+		observer(network)
+	}
+}
+
 // Appends or merges new data in networks table.
+// Time series keep raw values, table keeps values smoothed per network.
 func (ds *DataSource) Add(newData *netdata.Network) {
 	ds.tableLock.Lock()
 	defer ds.tableLock.Unlock()
@@ -69,51 +203,166 @@ func (ds *DataSource) Add(newData *netdata.Network) {
 		defer ds.tsLock.Unlock()
 
 		if _, found := ds.ts[netKey]; !found {
-			ds.ts[netKey] = map[string]ts.TimeSeries{
-				fieldKey: ts.New(defaultTimeSeriesSize).Add(val, timestamp),
-			}
+			ds.ts[netKey] = map[string]ts.TimeSeries{}
+			ds.summaries[netKey] = map[string]ts.Summary{}
+		}
+		ds.summaries[netKey][fieldKey] = ds.summaries[netKey][fieldKey].Add(val)
 
-			return
+		series, found := ds.ts[netKey][fieldKey]
+		if !found {
+			series = ts.New(defaultTimeSeriesSize)
 		}
 
-		ds.ts[netKey][fieldKey] = ds.ts[netKey][fieldKey].Add(val, timestamp)
+		ds.ts[netKey][fieldKey] = series.Add(val, timestamp)
 	}
 
 	key := newData.Key()
 
+	// raw samples
+	addMetric(key, netdata.RSSIKey, float64(newData.RSSI), newData.Timestamp)
+	addMetric(key, netdata.QualityKey, float64(newData.Quality), newData.Timestamp)
+	addMetric(key, netdata.NoiseKey, float64(newData.Noise), newData.Timestamp)
+	addMetric(key, netdata.SNRKey, float64(newData.SNR), newData.Timestamp)
+	if newData.BSSLoad.BSSLoadValid {
+		addMetric(key, netdata.UtilKey, float64(newData.BSSLoad.Utilization()), newData.Timestamp)
+	}
+
+	smoother, found := ds.smoothers[key]
+	if !found {
+		smoother = newSignalSmoother(ds.smoothing)
+		ds.smoothers[key] = smoother
+	}
+	smoother.Apply(newData)
+
+	if newData.Timestamp.After(ds.latest) {
+		ds.latest = newData.Timestamp
+	}
+
 	var entry *netdata.Network
-	var found bool
 	if entry, found = ds.table[key]; !found {
 		// Copy data
 		ds.table[key] = newData
 
-		// new timeseries
-		addMetric(key, netdata.RSSIKey, float64(newData.RSSI), time.Now())
-		addMetric(key, netdata.QualityKey, float64(newData.Quality), time.Now())
+		newData.FirstSeen = newData.Timestamp
+		newData.Frames = 1
+		newData.OffChannelFrames = uint(conv.BoolToInt(newData.OffChannel))
 
 		return
 	}
 
 	// merge network with existing
-	{
-		entry = &*newData
-		// TODO: use avarage for quality and noise
-		ds.table[key] = entry
+	ds.table[key] = merge(entry, newData)
+}
+
+// Removes networks not seen longer than TTL along with their time series.
+func (ds *DataSource) Evict() {
+	if ds.ttl <= 0 {
+		return
+	}
+
+	now := ds.Now()
+
+	ds.tableLock.Lock()
+	defer ds.tableLock.Unlock()
+
+	ds.tsLock.Lock()
+	defer ds.tsLock.Unlock()
+
+	for key, entry := range ds.table {
+		if now.Sub(entry.Timestamp) > ds.ttl {
+			delete(ds.table, key)
+			delete(ds.ts, key)
+			delete(ds.summaries, key)
+			delete(ds.smoothers, key)
+		}
+	}
+
+	for mac, client := range ds.clients {
+		if now.Sub(client.Timestamp) > ds.ttl {
+			delete(ds.clients, mac)
+		}
+	}
+}
+
+// Appends or merges client frame in clients table.
+// Signal is updated only by frames transmitted by client.
+func (ds *DataSource) AddClient(frame *wifi.ClientFrame) {
+	ds.tableLock.Lock()
+	defer ds.tableLock.Unlock()
+
+	mac := frame.Station.String()
+	client, found := ds.clients[mac]
+	if !found {
+		client = &netdata.Client{
+			MAC:       mac,
+			FirstSeen: frame.Timestamp,
+		}
+		client.Manuf, client.ManufLong = manuf.Lookup(mac)
+		ds.clients[mac] = client
+	}
+
+	client.Timestamp = frame.Timestamp
+	client.Frames++
+	// probe requests do not change association
+	if len(frame.BSSID) > 0 {
+		client.BSSID = frame.BSSID.String()
+	}
+	if frame.FromClient {
+		client.RSSI = frame.RSSI
+	}
+	client.AddProbedSSID(frame.ProbedSSID)
+	if len(frame.AssociationSSID) > 0 {
+		ds.reveal(frame)
+	}
 
-		// append timeseries
-		addMetric(key, netdata.RSSIKey, float64(newData.RSSI), time.Now())
-		addMetric(key, netdata.QualityKey, float64(newData.Quality), time.Now())
+	if frame.Timestamp.After(ds.latest) {
+		ds.latest = frame.Timestamp
+	}
+}
 
+// Reveals name of hidden network requested by station in association request.
+func (ds *DataSource) reveal(frame *wifi.ClientFrame) {
+	channel := wifi.FrequencyToChannel(frame.Frequency)
+	key := netdata.NewKey(frame.BSSID.String(), wifi.GetBand(frame.Frequency, channel).Range())
+
+	entry, found := ds.table[key]
+	if !found || len(entry.NetworkName) > 0 {
 		return
 	}
+
+	// network data is shared with copies returned to widgets
+	revealed := *entry
+	revealed.NetworkName = frame.AssociationSSID
+	revealed.SSIDs = mergeSSIDs(entry.SSIDs,
+		[]netdata.SSIDChange{{SSID: frame.AssociationSSID, Timestamp: frame.Timestamp}}, entry.NetworkName)
+	ds.table[key] = &revealed
 }
 
-// Returns network data slice.
+// Returns network data slice with numbers of associated clients and regulatory issues.
 func (ds *DataSource) Networks() netdata.Slice {
 	ds.tableLock.RLock()
 	defer ds.tableLock.RUnlock()
 
-	return ds.table.Slice()
+	networks := ds.table.Slice()
+	ds.checker.Check(networks)
+	if len(ds.clients) == 0 {
+		return networks
+	}
+
+	counts := ds.clients.CountByBSSID()
+	for i := range networks {
+		networks[i].Clients = counts[networks[i].BSSID]
+	}
+
+	return networks
+}
+
+// Returns client data slice.
+func (ds *DataSource) Clients() netdata.ClientSlice {
+	ds.tableLock.RLock()
+	defer ds.tableLock.RUnlock()
+
+	return ds.clients.Slice()
 }
 
 func (ds *DataSource) TimeSeries(netKey netdata.Key) func(colKey string) ts.TimeSeries {
@@ -135,6 +384,21 @@ func (ds *DataSource) TimeSeries(netKey netdata.Key) func(colKey string) ts.Time
 	}
 }
 
+// Returns statistics of all samples of network, time series keep only the latest ones.
+func (ds *DataSource) Stats(netKey netdata.Key) func(colKey string) ts.Stats {
+	ds.tsLock.RLock()
+	defer ds.tsLock.RUnlock()
+
+	copied := make(map[string]ts.Stats, len(ds.summaries[netKey]))
+	for key, summary := range ds.summaries[netKey] {
+		copied[key] = summary.Stats()
+	}
+
+	return func(colKey string) ts.Stats {
+		return copied[colKey]
+	}
+}
+
 // Alias for network data converter.
 type frameConverter wifi.Frame
 
@@ -142,14 +406,28 @@ type frameConverter wifi.Frame
 func (frame frameConverter) Network() *netdata.Network {
 	entry := &netdata.Network{
 		BSSID:            frame.BSSID.String(),
-		NetworkName:      frame.SSID,
+		NetworkName:      frame.SSID.String(),
+		Hidden:           frame.HiddenSSID,
 		Channel:          frame.Channel,
+		Frequency:        frame.Frequency,
 		FrequencyCenter0: frame.ChannelCenterSegment0,
 		FrequencyCenter1: frame.ChannelCenterSegment1,
 		Offset:           wifi.SecondaryChannelOffset(frame.SecondaryChannelOffset),
 		RSSI:             frame.RSSI,
 		Noise:            frame.Noise,
 		SNR:              frame.RSSI - frame.Noise,
+		Security:         wifi.GetSecurity(frame.CapabilityInfo, frame.SecurityIE),
+		SecurityIE:       frame.SecurityIE,
+		CapabilityInfo:   frame.CapabilityInfo,
+		IE:               frame.InformationElements,
+		BSSLoad:          frame.BSSLoadIE,
+		Country:          frame.CountryIE,
+		WPS:              frame.WPSIE,
+		Timestamp:        frame.Timestamp,
+	}
+
+	if wifi.CarriesSSID(frame.Dot11Type) {
+		entry.SSIDs = []netdata.SSIDChange{{SSID: frame.SSID.String(), Timestamp: frame.Timestamp}}
 	}
 
 	entry.Manuf, entry.ManufLong = manuf.Lookup(frame.BSSID.String())
@@ -157,10 +435,16 @@ func (frame frameConverter) Network() *netdata.Network {
 		RSSI: entry.RSSI,
 		SNR:  entry.SNR,
 	}.SignalQuality()
-	entry.Band = wifi.GetBandByChan(frame.Channel)
+	// channel is unknown without DS Set and HT/VHT Operation elements, e.g. in association response
+	if entry.Channel == 0 {
+		entry.Channel = wifi.FrequencyToChannel(frame.Frequency)
+	}
+	entry.OffChannel = wifi.OffChannel(frame.Channel, frame.Frequency, frame.HE6GHzDuplicateBeacon)
+	entry.Band = wifi.GetBand(frame.Frequency, entry.Channel)
 	entry.WidthOperation = wifi.GetChannelWidthOperation(frame.ChannelWidth)
 	entry.ChannelWidth = wifi.GetChannelWidth(wifi.Frame(frame))
 	entry.WidthOperation = wifi.GetChannelWidthOperation(frame.ChannelWidth)
+	entry.PHY = wifi.GetPHY(wifi.Frame(frame))
 
 	return entry
 }
@@ -0,0 +1,70 @@
+package ds
+
+import (
+	netdata "wfmon/pkg/data/net"
+	"wfmon/pkg/utils/conv"
+)
+
+const maxSSIDChanges = 16
+
+// Merges observation of network with existing network data.
+// Observation of frame without SSID element, e.g. association response, has no SSIDs:
+// it updates only signal and capture time, as it lacks security and operation elements.
+func merge(prev, next *netdata.Network) *netdata.Network {
+	entry := next
+	if len(next.SSIDs) == 0 {
+		partial := *prev
+		partial.RSSI, partial.Noise, partial.SNR, partial.Quality = next.RSSI, next.Noise, next.SNR, next.Quality
+		partial.Frequency, partial.OffChannel = next.Frequency, next.OffChannel
+		partial.Timestamp = next.Timestamp
+		entry = &partial
+	}
+
+	entry.FirstSeen = prev.FirstSeen
+	entry.Frames = prev.Frames + 1
+	entry.OffChannelFrames = prev.OffChannelFrames + uint(conv.BoolToInt(next.OffChannel))
+	entry.SSIDs = mergeSSIDs(prev.SSIDs, next.SSIDs, prev.NetworkName)
+
+	// hidden network keeps name revealed before, as well as revealed network keeps hidden flag
+	if len(entry.NetworkName) == 0 {
+		entry.NetworkName = prev.NetworkName
+	}
+	entry.Hidden = entry.Hidden || prev.Hidden
+
+	// channel derived from frequency is less accurate than advertised one
+	if entry.ChannelFromFrequency() && !prev.ChannelFromFrequency() {
+		entry.Channel, entry.Band, entry.ChannelWidth = prev.Channel, prev.Band, prev.ChannelWidth
+		entry.WidthOperation, entry.Offset = prev.WidthOperation, prev.Offset
+		entry.FrequencyCenter0, entry.FrequencyCenter1 = prev.FrequencyCenter0, prev.FrequencyCenter1
+	}
+
+	// BSS Load and Country are not advertised in every frame, e.g. probe response
+	if !entry.BSSLoad.BSSLoadValid {
+		entry.BSSLoad = prev.BSSLoad
+	}
+	if !entry.Country.Present() {
+		entry.Country = prev.Country
+	}
+	entry.WPS = entry.WPS.Merge(prev.WPS)
+
+	return entry
+}
+
+// Appends observed SSID to history if network is seen first, its name is revealed or changed.
+// Hidden beacons of network with known name are not a change.
+func mergeSSIDs(history, observed []netdata.SSIDChange, name string) []netdata.SSIDChange {
+	if len(observed) == 0 {
+		return history
+	}
+
+	ssid := observed[len(observed)-1]
+	if len(history) > 0 && (len(ssid.SSID) == 0 || ssid.SSID == name) {
+		return history
+	}
+
+	// history is shared with copies of network data returned to widgets
+	merged := make([]netdata.SSIDChange, 0, len(history)+1)
+	merged = append(merged, history[max(0, len(history)+1-maxSSIDChanges):]...)
+
+	return append(merged, ssid)
+}
@@ -0,0 +1,186 @@
+package ds
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	netdata "wfmon/pkg/data/net"
+)
+
+const (
+	DefaultSmoothingAlpha  = 0.3
+	DefaultSmoothingWindow = 10
+)
+
+// Strategy of smoothing signal values of consecutive frames.
+type SmoothingMode uint8
+
+const (
+	SmoothingLast   SmoothingMode = iota // value of the latest frame
+	SmoothingEWMA                        // exponentially weighted moving average
+	SmoothingMean                        // mean of sliding window
+	SmoothingMedian                      // median of sliding window
+)
+
+func (m SmoothingMode) String() string {
+	return []string{
+		SmoothingLast:   "last",
+		SmoothingEWMA:   "ewma",
+		SmoothingMean:   "mean",
+		SmoothingMedian: "median",
+	}[m]
+}
+
+// Returns short mark of the mode for column titles, empty for last value.
+func (m SmoothingMode) Mark() string {
+	return []string{
+		SmoothingLast:   "",
+		SmoothingEWMA:   "ᵉ",
+		SmoothingMean:   "ᵃ",
+		SmoothingMedian: "ᵐ",
+	}[m]
+}
+
+// Parses smoothing mode, returns last by default.
+func ParseSmoothingMode(s string) (SmoothingMode, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if len(s) == 0 {
+		return SmoothingLast, nil
+	}
+
+	for _, mode := range []SmoothingMode{SmoothingLast, SmoothingEWMA, SmoothingMean, SmoothingMedian} {
+		if s == mode.String() {
+			return mode, nil
+		}
+	}
+
+	return SmoothingLast, fmt.Errorf("unknown smoothing '%s'", s)
+}
+
+// Smoothing configuration.
+type Smoothing struct {
+	Mode   SmoothingMode
+	Alpha  float64 // weight of new value in EWMA, (0, 1]
+	Window int     // number of values in sliding window
+}
+
+// Returns smoothing with the latest values.
+func DefaultSmoothing() Smoothing {
+	return Smoothing{
+		Mode:   SmoothingLast,
+		Alpha:  DefaultSmoothingAlpha,
+		Window: DefaultSmoothingWindow,
+	}
+}
+
+// Returns smoothing presentation, e.g. ewma 0.3 or median 10.
+func (s Smoothing) String() string {
+	switch s.Mode { //nolint:exhaustive // ignore
+	case SmoothingEWMA:
+		return fmt.Sprintf("%s %g", s.Mode, s.Alpha)
+	case SmoothingMean, SmoothingMedian:
+		return fmt.Sprintf("%s %d", s.Mode, s.Window)
+	default:
+		return s.Mode.String()
+	}
+}
+
+// Returns error if parameters of the mode are out of range.
+func (s Smoothing) Validate() error {
+	switch {
+	case s.Mode == SmoothingEWMA && (s.Alpha <= 0 || s.Alpha > 1):
+		return fmt.Errorf("smoothing alpha %g out of range (0, 1]", s.Alpha)
+	case (s.Mode == SmoothingMean || s.Mode == SmoothingMedian) && s.Window < 1:
+		return fmt.Errorf("smoothing window %d should be positive", s.Window)
+	default:
+		return nil
+	}
+}
+
+// Smoothed value of a single field.
+type smoother struct {
+	smoothing Smoothing
+	value     float64
+	window    []float64
+	started   bool
+}
+
+// Adds raw value, returns smoothed one.
+func (s *smoother) Add(val float64) float64 {
+	switch s.smoothing.Mode {
+	case SmoothingEWMA:
+		if !s.started {
+			s.value, s.started = val, true
+		} else {
+			s.value = s.smoothing.Alpha*val + (1-s.smoothing.Alpha)*s.value
+		}
+
+	case SmoothingMean, SmoothingMedian:
+		s.window = append(s.window, val)
+		if len(s.window) > s.smoothing.Window {
+			s.window = s.window[len(s.window)-s.smoothing.Window:]
+		}
+		s.value = s.aggregate()
+
+	default:
+		s.value = val
+	}
+
+	return s.value
+}
+
+// Returns mean or median of sliding window.
+func (s *smoother) aggregate() float64 {
+	if s.smoothing.Mode == SmoothingMean {
+		sum := 0.0
+		for _, v := range s.window {
+			sum += v
+		}
+		return sum / float64(len(s.window))
+	}
+
+	sorted := slices.Clone(s.window)
+	slices.Sort(sorted)
+
+	mid := len(sorted) / 2 //nolint:gomnd // ignore
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2 //nolint:gomnd // ignore
+	}
+	return sorted[mid]
+}
+
+// Smoothed signal values of a network.
+type signalSmoother struct {
+	rssi  smoother
+	noise smoother
+}
+
+func newSignalSmoother(smoothing Smoothing) *signalSmoother {
+	return &signalSmoother{
+		rssi:  smoother{smoothing: smoothing},
+		noise: smoother{smoothing: smoothing},
+	}
+}
+
+// Replaces RSSI, noise, SNR and quality of network with smoothed values.
+// SNR and quality are derived from smoothed RSSI and noise.
+func (s *signalSmoother) Apply(network *netdata.Network) {
+	if s.rssi.smoothing.Mode == SmoothingLast {
+		return
+	}
+
+	network.RSSI = roundInt8(s.rssi.Add(float64(network.RSSI)))
+	network.Noise = roundInt8(s.noise.Add(float64(network.Noise)))
+	network.SNR = network.RSSI - network.Noise
+	network.Quality = netdata.QualityConverter{
+		RSSI: network.RSSI,
+		SNR:  network.SNR,
+	}.SignalQuality()
+}
+
+func roundInt8(val float64) int8 {
+	if val < 0 {
+		return int8(val - 0.5) //nolint:gomnd // ignore
+	}
+	return int8(val + 0.5) //nolint:gomnd // ignore
+}
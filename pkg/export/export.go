@@ -0,0 +1,181 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	netdata "wfmon/pkg/data/net"
+	"wfmon/pkg/ds"
+	"wfmon/pkg/ts"
+)
+
+// Signal statistics of all samples captured.
+type Stats struct {
+	Min float64 `json:"min"`
+	Avg float64 `json:"avg"`
+	Max float64 `json:"max"`
+}
+
+// Exported network: flat record, remaining network fields and signal statistics.
+type Entry struct {
+	netdata.Record
+	ManufShort       string `json:"manuf_short,omitempty"`
+	Frequency        int    `json:"frequency"`
+	Offset           string `json:"offset"`
+	WidthOperation   string `json:"width_operation"`
+	FrequencyCenter0 uint8  `json:"center0"`
+	FrequencyCenter1 uint8  `json:"center1"`
+	Capabilities     string `json:"capabilities"`
+	Samples          int    `json:"samples"`
+	RSSIStats        Stats  `json:"rssi_stats"`
+	NoiseStats       Stats  `json:"noise_stats"`
+	SNRStats         Stats  `json:"snr_stats"`
+
+	SSIDHistory []netdata.SSIDChange `json:"ssid_history,omitempty"`
+
+	network *netdata.Network
+}
+
+// Returns entries of networks with statistics of all their samples.
+func Entries(networks netdata.Slice, provider ds.StatsProvider) []Entry {
+	entries := make([]Entry, len(networks))
+	for i := range networks {
+		n := &networks[i]
+		stats := provider.Stats(n.Key())
+		rssi := stats(netdata.RSSIKey)
+
+		entries[i] = Entry{
+			Record:           n.Record(),
+			ManufShort:       n.Manuf,
+			Frequency:        n.Frequency,
+			Offset:           n.Offset.String(),
+			WidthOperation:   n.WidthOperation.String(),
+			FrequencyCenter0: n.FrequencyCenter0,
+			FrequencyCenter1: n.FrequencyCenter1,
+			Capabilities:     n.CapabilityInfo.String(),
+			Samples:          rssi.Count,
+			RSSIStats:        newStats(rssi, n.RSSI),
+			NoiseStats:       newStats(stats(netdata.NoiseKey), n.Noise),
+			SNRStats:         newStats(stats(netdata.SNRKey), n.SNR),
+			SSIDHistory:      n.SSIDs,
+			network:          n,
+		}
+	}
+
+	return entries
+}
+
+// Returns statistics of samples, current value if there are no samples.
+func newStats(st ts.Stats, cur int8) Stats {
+	if st.Count == 0 {
+		return Stats{Min: float64(cur), Avg: float64(cur), Max: float64(cur)}
+	}
+
+	return Stats{Min: st.Min, Avg: st.Avg, Max: st.Max}
+}
+
+// Writes networks with statistics of all their samples in given format.
+func Write(w io.Writer, format Format, networks netdata.Slice, provider ds.StatsProvider) error {
+	entries := Entries(networks, provider)
+
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, entries)
+	case FormatNetXML:
+		return writeNetXML(w, entries)
+	default:
+		return writeJSON(w, entries)
+	}
+}
+
+func writeJSON(w io.Writer, entries []Entry) error {
+	encoder := json.NewEncoder(w)
+	for i := range entries {
+		if err := encoder.Encode(&entries[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CSV column: header and value of entry.
+type csvColumn struct {
+	name  string
+	value func(e *Entry) string
+}
+
+func csvColumns() []csvColumn {
+	itoa := func(v int) string { return strconv.Itoa(v) }
+	ftoa := func(v float64) string { return strconv.FormatFloat(v, 'f', 1, 64) }
+	stamp := func(t time.Time) string { return t.Format(time.RFC3339) }
+
+	return []csvColumn{
+		{"first_seen", func(e *Entry) string { return stamp(e.FirstSeen) }},
+		{"last_seen", func(e *Entry) string { return stamp(e.Timestamp) }},
+		{"frames", func(e *Entry) string { return itoa(int(e.Frames)) }},
+		{"bssid", func(e *Entry) string { return e.BSSID }},
+		{"ssid", func(e *Entry) string { return e.SSID }},
+		{"hidden", func(e *Entry) string { return strconv.FormatBool(e.Hidden) }},
+		{"manuf_short", func(e *Entry) string { return e.ManufShort }},
+		{"manuf", func(e *Entry) string { return e.Manuf }},
+		{"channel", func(e *Entry) string { return itoa(int(e.Channel)) }},
+		{"frequency", func(e *Entry) string { return itoa(e.Frequency) }},
+		{"width", func(e *Entry) string { return itoa(int(e.Width)) }},
+		{"width_operation", func(e *Entry) string { return e.WidthOperation }},
+		{"offset", func(e *Entry) string { return e.Offset }},
+		{"center0", func(e *Entry) string { return itoa(int(e.FrequencyCenter0)) }},
+		{"center1", func(e *Entry) string { return itoa(int(e.FrequencyCenter1)) }},
+		{"band", func(e *Entry) string { return e.Band }},
+		{"phy", func(e *Entry) string { return e.PHY }},
+		{"security", func(e *Entry) string { return e.Security }},
+		{"capabilities", func(e *Entry) string { return e.Capabilities }},
+		{"rssi", func(e *Entry) string { return itoa(int(e.RSSI)) }},
+		{"noise", func(e *Entry) string { return itoa(int(e.Noise)) }},
+		{"snr", func(e *Entry) string { return itoa(int(e.SNR)) }},
+		{"quality", func(e *Entry) string { return itoa(int(e.Quality)) }},
+		{"clients", func(e *Entry) string { return itoa(e.Clients) }},
+		{"country", func(e *Entry) string { return e.Country }},
+		{"wps_name", func(e *Entry) string { return e.WPSName }},
+		{"wps_model", func(e *Entry) string { return e.WPSModel }},
+		{"reg_issues", func(e *Entry) string { return strings.Join(e.RegIssues, "; ") }},
+		{"samples", func(e *Entry) string { return itoa(e.Samples) }},
+		{"rssi_min", func(e *Entry) string { return ftoa(e.RSSIStats.Min) }},
+		{"rssi_avg", func(e *Entry) string { return ftoa(e.RSSIStats.Avg) }},
+		{"rssi_max", func(e *Entry) string { return ftoa(e.RSSIStats.Max) }},
+		{"noise_min", func(e *Entry) string { return ftoa(e.NoiseStats.Min) }},
+		{"noise_avg", func(e *Entry) string { return ftoa(e.NoiseStats.Avg) }},
+		{"noise_max", func(e *Entry) string { return ftoa(e.NoiseStats.Max) }},
+		{"snr_min", func(e *Entry) string { return ftoa(e.SNRStats.Min) }},
+		{"snr_avg", func(e *Entry) string { return ftoa(e.SNRStats.Avg) }},
+		{"snr_max", func(e *Entry) string { return ftoa(e.SNRStats.Max) }},
+	}
+}
+
+func writeCSV(w io.Writer, entries []Entry) error {
+	columns := csvColumns()
+	writer := csv.NewWriter(w)
+
+	row := make([]string, len(columns))
+	for i, col := range columns {
+		row[i] = col.name
+	}
+	if err := writer.Write(row); err != nil {
+		return err
+	}
+
+	for i := range entries {
+		for j, col := range columns {
+			row[j] = col.value(&entries[i])
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
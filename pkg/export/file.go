@@ -0,0 +1,99 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	netdata "wfmon/pkg/data/net"
+	"wfmon/pkg/ds"
+	"wfmon/pkg/filter"
+)
+
+const (
+	filenameLayout = "20060102-150405"
+	maxFileSuffix  = 1000 // max number of files exported within a second
+)
+
+// Returns timestamped file name, e.g. wfmon-20240131-150405.csv.
+func Filename(format Format, t time.Time) string {
+	return "wfmon-" + t.Format(filenameLayout) + format.Ext()
+}
+
+// Creates file and writes networks in given format, existing file is overwritten.
+func WriteFile(path string, format Format, networks netdata.Slice, provider ds.StatsProvider) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error while creating export file: %w", err)
+	}
+
+	return writeFile(file, format, networks, provider)
+}
+
+// Writes networks in given format and closes file.
+func writeFile(file *os.File, format Format, networks netdata.Slice, provider ds.StatsProvider) error {
+	if err := Write(file, format, networks, provider); err != nil {
+		file.Close()
+		return fmt.Errorf("error while exporting networks: %w", err)
+	}
+
+	return file.Close()
+}
+
+// Creates a new file, never overwrites existing one: adds -N suffix to the name if file exists,
+// e.g. wfmon-20240131-150405-1.csv.
+func createNewFile(path string) (*os.File, error) {
+	ext := filepath.Ext(path)
+	name := path
+	for n := 1; n <= maxFileSuffix; n++ {
+		file, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644) //nolint:gomnd,gosec // ignore
+		if !errors.Is(err, fs.ErrExist) {
+			return file, err
+		}
+		name = fmt.Sprintf("%s-%d%s", strings.TrimSuffix(path, ext), n, ext)
+	}
+
+	return nil, fmt.Errorf("%w: %s", fs.ErrExist, path)
+}
+
+type FileConfig struct {
+	Dir    string         // directory of exported files, current if empty
+	Format Format         // format of exported files
+	Filter *filter.Filter // exports only matched networks, all if nil
+}
+
+// Writes current networks of provider to timestamped files on demand.
+type FileExporter struct {
+	dir      string
+	format   Format
+	filter   *filter.Filter
+	provider ds.Provider
+}
+
+func NewFileExporter(cfg *FileConfig, provider ds.Provider) *FileExporter {
+	return &FileExporter{
+		dir:      cfg.Dir,
+		format:   cfg.Format,
+		filter:   cfg.Filter,
+		provider: provider,
+	}
+}
+
+// Writes networks to a new timestamped file, returns its path.
+// Files exported within the same second get -N suffix instead of overwriting each other.
+func (e *FileExporter) Export() (string, error) {
+	file, err := createNewFile(filepath.Join(e.dir, Filename(e.format, time.Now())))
+	if err != nil {
+		return "", fmt.Errorf("error while creating export file: %w", err)
+	}
+
+	networks := e.filter.Apply(e.provider.Networks())
+	if err = writeFile(file, e.format, networks, e.provider); err != nil {
+		return "", err
+	}
+
+	return file.Name(), nil
+}
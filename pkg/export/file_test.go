@@ -0,0 +1,58 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"wfmon/pkg/ds"
+)
+
+func TestCreateNewFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "wfmon-20240131-150405.csv")
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"new file", "wfmon-20240131-150405.csv"},
+		{"first collision", "wfmon-20240131-150405-1.csv"},
+		{"second collision", "wfmon-20240131-150405-2.csv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := createNewFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			file.Close()
+
+			if got := filepath.Base(file.Name()); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileExporterKeepsPreviousExport(t *testing.T) {
+	exporter := NewFileExporter(&FileConfig{Dir: t.TempDir(), Format: FormatCSV}, ds.EmptyProvider{})
+
+	first, err := exporter.Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := exporter.Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first == second {
+		t.Fatalf("second export overwrote %s", first)
+	}
+	for _, path := range []string{first, second} {
+		if _, err = os.Stat(path); err != nil {
+			t.Error(err)
+		}
+	}
+}
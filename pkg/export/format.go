@@ -0,0 +1,44 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+)
+
+// File format of exported networks.
+type Format uint8
+
+const (
+	FormatJSON   Format = iota // JSON lines, a record per network
+	FormatCSV                  // comma separated values with header
+	FormatNetXML               // Kismet netxml
+)
+
+func (f Format) String() string {
+	return []string{
+		FormatJSON:   "json",
+		FormatCSV:    "csv",
+		FormatNetXML: "netxml",
+	}[f]
+}
+
+// Returns file extension of format, e.g. .csv.
+func (f Format) Ext() string {
+	return "." + f.String()
+}
+
+// Parses export format, returns JSON by default.
+func ParseFormat(s string) (Format, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if len(s) == 0 {
+		return FormatJSON, nil
+	}
+
+	for _, format := range []Format{FormatJSON, FormatCSV, FormatNetXML} {
+		if s == format.String() {
+			return format, nil
+		}
+	}
+
+	return FormatJSON, fmt.Errorf("unknown export format '%s'", s)
+}
@@ -0,0 +1,207 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+	netdata "wfmon/pkg/data/net"
+	"wfmon/pkg/wifi"
+)
+
+// Kismet netxml document.
+// https://www.kismetwireless.net/docs/legacy/
+type netXML struct {
+	XMLName   xml.Name        `xml:"detection-run"`
+	Version   string          `xml:"kismet-version,attr"`
+	StartTime string          `xml:"start-time,attr"`
+	Networks  []netXMLNetwork `xml:"wireless-network"`
+}
+
+type netXMLNetwork struct {
+	Number    int           `xml:"number,attr"`
+	Type      string        `xml:"type,attr"`
+	FirstTime string        `xml:"first-time,attr"`
+	LastTime  string        `xml:"last-time,attr"`
+	SSID      netXMLSSID    `xml:"SSID"`
+	BSSID     string        `xml:"BSSID"`
+	Manuf     string        `xml:"manuf"`
+	Channel   uint8         `xml:"channel"`
+	FreqMHz   string        `xml:"freqmhz,omitempty"`
+	Packets   netXMLPackets `xml:"packets"`
+	SNRInfo   netXMLSNRInfo `xml:"snr-info"`
+}
+
+type netXMLSSID struct {
+	FirstTime  string      `xml:"first-time,attr"`
+	LastTime   string      `xml:"last-time,attr"`
+	Type       string      `xml:"type"`
+	MaxRate    string      `xml:"max-rate"`
+	Packets    uint        `xml:"packets"`
+	Encryption []string    `xml:"encryption"`
+	ESSID      netXMLESSID `xml:"essid"`
+}
+
+type netXMLESSID struct {
+	Cloaked bool   `xml:"cloaked,attr"`
+	Name    string `xml:",chardata"`
+}
+
+type netXMLPackets struct {
+	Total uint `xml:"total"`
+}
+
+type netXMLSNRInfo struct {
+	LastSignal int `xml:"last_signal_dbm"`
+	LastNoise  int `xml:"last_noise_dbm"`
+	MinSignal  int `xml:"min_signal_dbm"`
+	MinNoise   int `xml:"min_noise_dbm"`
+	MaxSignal  int `xml:"max_signal_dbm"`
+	MaxNoise   int `xml:"max_noise_dbm"`
+}
+
+func writeNetXML(w io.Writer, entries []Entry) error {
+	doc := netXML{
+		Version:  "wfmon",
+		Networks: make([]netXMLNetwork, len(entries)),
+	}
+
+	var start time.Time
+	for i := range entries {
+		e := &entries[i]
+		if start.IsZero() || e.FirstSeen.Before(start) {
+			start = e.FirstSeen
+		}
+
+		doc.Networks[i] = netXMLNetwork{
+			Number:    i + 1,
+			Type:      networkType(e.network.CapabilityInfo),
+			FirstTime: formatNetXMLTime(e.FirstSeen),
+			LastTime:  formatNetXMLTime(e.Timestamp),
+			SSID: netXMLSSID{
+				FirstTime:  formatNetXMLTime(e.FirstSeen),
+				LastTime:   formatNetXMLTime(e.Timestamp),
+				Type:       "Beacon",
+				MaxRate:    fmt.Sprintf("%f", maxRate(e.network.IE.Rates)),
+				Packets:    e.Frames,
+				Encryption: encryption(e.network),
+				ESSID: netXMLESSID{
+					Cloaked: e.Hidden || len(e.SSID) == 0,
+					Name:    e.SSID,
+				},
+			},
+			BSSID:   e.BSSID,
+			Manuf:   e.Manuf,
+			Channel: e.Channel,
+			Packets: netXMLPackets{Total: e.Frames},
+			SNRInfo: netXMLSNRInfo{
+				LastSignal: int(e.RSSI),
+				LastNoise:  int(e.Noise),
+				MinSignal:  int(e.RSSIStats.Min),
+				MinNoise:   int(e.NoiseStats.Min),
+				MaxSignal:  int(e.RSSIStats.Max),
+				MaxNoise:   int(e.NoiseStats.Max),
+			},
+		}
+		if e.Frequency > 0 {
+			// frequency followed by number of packets seen on it
+			doc.Networks[i].FreqMHz = fmt.Sprintf("%d %d", e.Frequency, e.Frames)
+		}
+	}
+	doc.StartTime = formatNetXMLTime(start)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// Returns time in ctime layout used by Kismet, e.g. Mon Jan  2 15:04:05 2006.
+func formatNetXMLTime(t time.Time) string {
+	return t.Format(time.ANSIC)
+}
+
+func networkType(capabilities wifi.CapabilityInfo) string {
+	if capabilities.IBSS() {
+		return "ad-hoc"
+	}
+
+	return "infrastructure"
+}
+
+// Returns max supported rate in Mbps.
+func maxRate(rates []uint8) float64 {
+	const (
+		basicFlag = 0x80
+		unitKbps  = 500
+	)
+
+	var rate uint8
+	for _, r := range rates {
+		rate = max(rate, r&^basicFlag)
+	}
+
+	return float64(rate) * unitKbps / 1000 //nolint:gomnd // ignore
+}
+
+// Returns Kismet encryption names, e.g. WPA+PSK and WPA+AES-CCM.
+func encryption(n *netdata.Network) []string {
+	sec := n.Security
+	if sec.Protocols == wifi.SecurityOpen {
+		return []string{"None"}
+	}
+	if sec.Protocols == wifi.SecurityWEP {
+		return []string{"WEP"}
+	}
+
+	names := []string{}
+	for _, auth := range []struct {
+		method wifi.AuthMethod
+		name   string
+	}{
+		{wifi.AuthPSK, "WPA+PSK"},
+		{wifi.AuthSAE, "WPA+SAE"},
+		{wifi.Auth8021X, "WPA+MGT"},
+		{wifi.AuthOWE, "WPA+OWE"},
+	} {
+		if sec.Auth&auth.method != 0 {
+			names = append(names, auth.name)
+		}
+	}
+
+	ciphers := map[string]bool{}
+	for _, ie := range []*wifi.RSNIE{&n.SecurityIE.RSN, &n.SecurityIE.WPA} {
+		if !ie.Present() {
+			continue
+		}
+		for _, c := range append([]wifi.CipherSuite{ie.GroupCipher}, ie.PairwiseCiphers...) {
+			switch c { //nolint:exhaustive // ignore
+			case wifi.CipherTKIP:
+				ciphers["WPA+TKIP"] = true
+			case wifi.CipherCCMP, wifi.CipherCCMP256:
+				ciphers["WPA+AES-CCM"] = true
+			case wifi.CipherGCMP128, wifi.CipherGCMP256:
+				ciphers["WPA+AES-GCM"] = true
+			}
+		}
+	}
+	for _, name := range []string{"WPA+TKIP", "WPA+AES-CCM", "WPA+AES-GCM"} {
+		if ciphers[name] {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		names = append(names, "WPA")
+	}
+
+	return names
+}
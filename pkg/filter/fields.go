@@ -0,0 +1,91 @@
+package filter
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	netdata "wfmon/pkg/data/net"
+	"wfmon/pkg/utils/conv"
+)
+
+// Value of network field used in comparison.
+type value struct {
+	num     float64
+	str     string
+	numeric bool
+}
+
+func numValue[T int | int8 | uint8 | uint16 | netdata.Quality](n T) value {
+	return value{num: float64(n), str: strconv.Itoa(int(n)), numeric: true}
+}
+
+func strValue(s string) value {
+	return value{str: s}
+}
+
+// Returns field value of network.
+type getter func(*netdata.Network) value
+
+// Returns getters of network fields by lowercase column keys.
+func fields() map[string]getter {
+	return map[string]getter{
+		strings.ToLower(netdata.SSIDKey):      func(n *netdata.Network) value { return strValue(n.NetworkName) },
+		strings.ToLower(netdata.BSSIDKey):     func(n *netdata.Network) value { return strValue(n.BSSID) },
+		strings.ToLower(netdata.ManufKey):     func(n *netdata.Network) value { return strValue(n.Manuf) },
+		strings.ToLower(netdata.ManufLongKey): func(n *netdata.Network) value { return strValue(n.ManufLong) },
+		strings.ToLower(netdata.ChanKey):      func(n *netdata.Network) value { return numValue(n.Channel) },
+		strings.ToLower(netdata.WidthKey):     func(n *netdata.Network) value { return numValue(n.ChannelWidth) },
+		strings.ToLower(netdata.BandKey):      bandValue,
+		strings.ToLower(netdata.RSSIKey):      func(n *netdata.Network) value { return numValue(n.RSSI) },
+		strings.ToLower(netdata.QualityKey):   func(n *netdata.Network) value { return numValue(n.Quality) },
+		strings.ToLower(netdata.BarsKey):      func(n *netdata.Network) value { return numValue(n.Quality) },
+		strings.ToLower(netdata.NoiseKey):     func(n *netdata.Network) value { return numValue(n.Noise) },
+		strings.ToLower(netdata.SNRKey):       func(n *netdata.Network) value { return numValue(n.SNR) },
+		strings.ToLower(netdata.SecurityKey):  func(n *netdata.Network) value { return strValue(n.Security.String()) },
+		strings.ToLower(netdata.PHYKey):       func(n *netdata.Network) value { return strValue(n.PHY.String()) },
+		strings.ToLower(netdata.ClientsKey):   func(n *netdata.Network) value { return numValue(n.Clients) },
+		strings.ToLower(netdata.StationsKey):  func(n *netdata.Network) value { return numValue(n.BSSLoad.StationCount) },
+		strings.ToLower(netdata.CountryKey):   func(n *netdata.Network) value { return strValue(n.Country.Country) },
+		strings.ToLower(netdata.HiddenKey):    func(n *netdata.Network) value { return numValue(conv.BoolToInt(n.Hidden)) },
+		// number of frames received off the advertised channel
+		"offchannel": func(n *netdata.Network) value { return numValue(int(n.OffChannelFrames)) },
+		// number of regulatory issues
+		"issues": func(n *netdata.Network) value { return numValue(len(n.RegIssues)) },
+		// WPS Name and WPS Model are not identifiers in expressions
+		"wpsname":  func(n *netdata.Network) value { return strValue(n.WPS.DeviceName) },
+		"wpsmodel": func(n *netdata.Network) value { return strValue(n.WPS.Model()) },
+		// Wi-Fi Direct group, 1 if P2P element is advertised
+		"p2p": func(n *netdata.Network) value { return numValue(conv.BoolToInt(n.IE.P2P)) },
+		// Util% is not an identifier in expressions
+		"util": func(n *netdata.Network) value { return numValue(n.BSSLoad.Utilization()) },
+	}
+}
+
+// Returns alternative names of fields.
+func aliases() map[string]string {
+	return map[string]string{
+		"ssid":    strings.ToLower(netdata.SSIDKey),
+		"channel": strings.ToLower(netdata.ChanKey),
+		"vendor":  strings.ToLower(netdata.ManufLongKey),
+	}
+}
+
+// Returns band range in GHz as number, e.g. 2.4, 5 or 6, and band name as string.
+func bandValue(n *netdata.Network) value {
+	num, err := strconv.ParseFloat(n.Band.Range(), 64)
+	return value{num: num, str: n.Band.String(), numeric: err == nil}
+}
+
+// Returns sorted field names supported in expressions.
+func Fields() []string {
+	names := []string{}
+	for name := range fields() {
+		names = append(names, name)
+	}
+	for name := range aliases() {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
@@ -0,0 +1,179 @@
+// Package filter implements expressions over network data fields,
+// e.g. band == 5 && rssi > -70 && width >= 80 && manuf ~ "Cisco".
+//
+// Field names are column keys of network data, case insensitive.
+// Numeric fields are compared as numbers, other fields as case insensitive strings.
+// Boolean fields are numbers 1 and 0, literals true and false are compared as such numbers.
+// Operator ~ (!~) matches (does not match) a case insensitive regular expression.
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	netdata "wfmon/pkg/data/net"
+	"wfmon/pkg/utils/cmp"
+)
+
+// Compiled filter expression.
+type Filter struct {
+	expr string
+	root node
+}
+
+// Compiles filter expression.
+func Compile(expr string) (*Filter, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, fields: fields(), alias: aliases()}
+	root, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Filter{expr: strings.TrimSpace(expr), root: root}, nil
+}
+
+// Returns true if network satisfies expression. Nil filter matches all networks.
+func (f *Filter) Match(network *netdata.Network) bool {
+	if f == nil {
+		return true
+	}
+
+	return f.root.eval(network)
+}
+
+// Returns networks satisfying expression.
+func (f *Filter) Apply(networks netdata.Slice) netdata.Slice {
+	if f == nil {
+		return networks
+	}
+
+	filtered := make(netdata.Slice, 0, len(networks))
+	for i := range networks {
+		if f.Match(&networks[i]) {
+			filtered = append(filtered, networks[i])
+		}
+	}
+
+	return filtered
+}
+
+// Returns source expression.
+func (f *Filter) String() string {
+	if f == nil {
+		return ""
+	}
+
+	return f.expr
+}
+
+// Node of expression tree.
+type node interface {
+	eval(network *netdata.Network) bool
+}
+
+type andNode struct {
+	left, right node
+}
+
+func (n andNode) eval(network *netdata.Network) bool {
+	return n.left.eval(network) && n.right.eval(network)
+}
+
+type orNode struct {
+	left, right node
+}
+
+func (n orNode) eval(network *netdata.Network) bool {
+	return n.left.eval(network) || n.right.eval(network)
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n notNode) eval(network *netdata.Network) bool {
+	return !n.operand.eval(network)
+}
+
+// Compares network field with literal.
+type compareNode struct {
+	get     getter
+	op      string
+	num     float64
+	str     string
+	numeric bool // literal is a number
+	re      *regexp.Regexp
+}
+
+func newCompareNode(get getter, op string, lit token) (node, error) {
+	n := compareNode{get: get, op: op, str: lit.text}
+
+	if lit.kind == tokenNumber {
+		num, err := strconv.ParseFloat(lit.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s' at %d", lit.text, lit.pos)
+		}
+		n.num, n.numeric = num, true
+	}
+
+	// boolean literal, e.g. hidden == true
+	if lit.kind != tokenNumber {
+		switch strings.ToLower(lit.text) {
+		case "true":
+			n.num, n.numeric = 1, true
+		case "false":
+			n.num, n.numeric = 0, true
+		}
+	}
+
+	if op == "~" || op == "!~" {
+		re, err := regexp.Compile("(?i)" + lit.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression '%s' at %d: %w", lit.text, lit.pos, err)
+		}
+		n.re = re
+	}
+
+	return n, nil
+}
+
+func (n compareNode) eval(network *netdata.Network) bool {
+	val := n.get(network)
+
+	switch n.op {
+	case "~":
+		return n.re.MatchString(val.str)
+	case "!~":
+		return !n.re.MatchString(val.str)
+	}
+
+	var res int
+	if val.numeric && n.numeric {
+		res = cmp.Compare(val.num, n.num)
+	} else {
+		res = cmp.Compare(strings.ToLower(val.str), strings.ToLower(n.str))
+	}
+
+	switch n.op {
+	case "==":
+		return res == 0
+	case "!=":
+		return res != 0
+	case "<":
+		return res < 0
+	case "<=":
+		return res <= 0
+	case ">":
+		return res > 0
+	case ">=":
+		return res >= 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,159 @@
+package filter
+
+import (
+	"testing"
+	netdata "wfmon/pkg/data/net"
+	"wfmon/pkg/wifi"
+)
+
+func testNetwork() *netdata.Network {
+	return &netdata.Network{
+		BSSID:        "00:11:22:33:44:55",
+		Manuf:        "Cisco",
+		NetworkName:  "Café",
+		Channel:      36,
+		ChannelWidth: 80,
+		Band:         wifi.UNII1,
+		RSSI:         -60,
+		Clients:      3,
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want bool
+	}{
+		// precedence
+		{"and binds tighter than or", "chan == 36 || chan == 1 && rssi > -50", true},
+		{"parentheses override precedence", "(chan == 36 || chan == 1) && rssi > -50", false},
+		{"and of or groups", "(chan == 1 || chan == 36) && (width == 40 || width == 80)", true},
+		{"nested parentheses", "((chan == 36))", true},
+
+		// negation
+		{"not binds to comparison", "!chan == 1 && chan == 36", true},
+		{"not of group", "!(chan == 36 || rssi < -70)", false},
+		{"double not", "!!hidden == 0", true},
+
+		// negative numbers
+		{"negative greater", "rssi > -70", true},
+		{"negative equal bound", "rssi <= -60", true},
+		{"negative fraction", "rssi < -60.5", false},
+		{"negative not equal", "rssi != -60", false},
+
+		// numeric vs string comparison
+		{"numeric field compared as number", "chan > 100", false},
+		{"leading zero is the same number", "chan == 036", true},
+		{"quoted number compared as string", `chan == "036"`, false},
+		{"band range as number", "band == 5", true},
+		{"band name as string", `band == "u-nii-1"`, true},
+		{"string case insensitive", "manuf == CISCO", true},
+		{"string ordering", `manuf < "Dlink"`, true},
+		{"alias of field", "channel == 36 && vendor == \"\"", true},
+
+		// boolean fields
+		{"boolean true", "hidden == true", false},
+		{"boolean false", "hidden == false", true},
+		{"boolean case insensitive", "hidden != TRUE", true},
+		{"boolean quoted", `p2p == "false"`, true},
+		{"boolean literal of string field", "ssid == true", false},
+
+		// regular expressions
+		{"match", `manuf ~ "^cis"`, true},
+		{"match case insensitive", "manuf ~ CISCO", true},
+		{"not match", "manuf !~ cisco", false},
+		{"not match other", `bssid !~ "^ff:"`, true},
+		{"match numeric field as string", `chan ~ "^3"`, true},
+
+		// UTF-8 literals
+		{"unquoted non-ASCII literal", "ssid == Café", true},
+		{"quoted non-ASCII literal", `ssid == "CAFÉ"`, true},
+		{"non-ASCII regular expression", `ssid ~ "é$"`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := Compile(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := f.Match(testNetwork()); got != tt.want {
+				t.Errorf("%s: got %t, want %t", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchHidden(t *testing.T) {
+	hidden := testNetwork()
+	hidden.Hidden = true
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"hidden == true", true},
+		{"hidden == 1", true},
+		{"hidden == false", false},
+		{"!(hidden == true)", false},
+	}
+
+	for _, tt := range tests {
+		f, err := Compile(tt.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := f.Match(hidden); got != tt.want {
+			t.Errorf("%s: got %t, want %t", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestCompileError(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want string
+	}{
+		{"unknown field", "foo == 1", "unknown field 'foo' at 0"},
+		{"unknown field after non-ASCII literal", "ssid == Café && foo == 1", "unknown field 'foo' at 17"},
+		{"unterminated string", `ssid == "abc`, "unterminated string at 8"},
+		{"unterminated string with escaped quote", `ssid == "abc\"`, "unterminated string at 8"},
+		{"missing operator", "chan 1", "expected operator after 'chan' at 5"},
+		{"missing value", "chan ==", "expected value after '==' at 7"},
+		{"missing closing parenthesis", "(chan == 1", "expected ')' at 10"},
+		{"extra closing parenthesis", "chan == 1)", "unexpected ')' at 9"},
+		{"missing operand", "chan == 1 &&", "unexpected end of expression"},
+		{"operand is not field", "1 == chan", "expected field name at 0, got '1'"},
+		{"invalid number", "rssi > -", "invalid number '-' at 7"},
+		{"unexpected character", "chan # 1", "unexpected character '#' at 5"},
+		{"unexpected non-ASCII character", "chan == 1 → 2", "unexpected character '→' at 10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.expr)
+			if err == nil {
+				t.Fatalf("%s: expected error %q", tt.expr, tt.want)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("%s: got error %q, want %q", tt.expr, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := Compile(`ssid ~ "["`); err == nil {
+		t.Error("expected error for invalid regular expression")
+	}
+}
+
+func TestNilFilter(t *testing.T) {
+	var f *Filter
+	if !f.Match(testNetwork()) {
+		t.Error("nil filter should match all networks")
+	}
+	if got := f.Apply(netdata.Slice{*testNetwork()}); len(got) != 1 {
+		t.Errorf("nil filter applied: got %d networks, want 1", len(got))
+	}
+}
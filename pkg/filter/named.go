@@ -0,0 +1,110 @@
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// Prefix of named filter reference, e.g. @office.
+	NamedPrefix = "@"
+
+	configDir      = "wfmon"
+	configFilename = "filters.json"
+)
+
+// Named filter expressions saved in config file.
+type Named map[string]string
+
+// Returns default config file path of named filters, e.g. ~/.config/wfmon/filters.json.
+func DefaultNamedPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+
+	return filepath.Join(dir, configDir, configFilename)
+}
+
+// Loads named filters from JSON file. Returns empty set if file does not exist.
+func LoadNamed(path string) (Named, error) {
+	named := Named{}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return named, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error while reading filters: %w", err)
+	}
+
+	if err = json.Unmarshal(data, &named); err != nil {
+		return nil, fmt.Errorf("error while parsing filters %s: %w", path, err)
+	}
+
+	return named, nil
+}
+
+// Saves named filters to JSON file, creates config directory if missing.
+func (n Named) Save(path string) error {
+	data, err := json.MarshalIndent(n, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint:gomnd // ignore
+		return fmt.Errorf("error while creating config directory: %w", err)
+	}
+
+	if err = os.WriteFile(path, append(data, '\n'), 0o644); err != nil { //nolint:gomnd,gosec // ignore
+		return fmt.Errorf("error while saving filters: %w", err)
+	}
+
+	return nil
+}
+
+// Validates and adds named filter.
+func (n Named) Set(name, expr string) error {
+	name = strings.TrimPrefix(strings.TrimSpace(name), NamedPrefix)
+	if len(name) == 0 {
+		return errors.New("empty filter name")
+	}
+
+	if _, err := Compile(expr); err != nil {
+		return fmt.Errorf("invalid filter %s: %w", name, err)
+	}
+
+	n[name] = strings.TrimSpace(expr)
+	return nil
+}
+
+// Returns expression of @name reference, or given expression itself.
+func (n Named) Resolve(expr string) (string, error) {
+	expr = strings.TrimSpace(expr)
+	if !strings.HasPrefix(expr, NamedPrefix) {
+		return expr, nil
+	}
+
+	name := strings.TrimPrefix(expr, NamedPrefix)
+	named, found := n[name]
+	if !found {
+		return "", fmt.Errorf("named filter '%s' not found", name)
+	}
+
+	return named, nil
+}
+
+// Compiles expression or @name reference. Returns nil filter for empty expression.
+func (n Named) Compile(expr string) (*Filter, error) {
+	expr, err := n.Resolve(expr)
+	if err != nil || len(expr) == 0 {
+		return nil, err
+	}
+
+	return Compile(expr)
+}
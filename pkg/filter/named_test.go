@@ -0,0 +1,69 @@
+package filter
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestNamedCompile(t *testing.T) {
+	named := Named{
+		"office": "chan == 36 && manuf ~ cisco",
+		"weak":   "rssi < -80",
+	}
+
+	tests := []struct {
+		name    string
+		expr    string
+		want    bool
+		wantErr bool
+	}{
+		{"reference", "@office", true, false},
+		{"reference with spaces", "  @weak ", false, false},
+		{"expression", "chan == 1", false, false},
+		{"empty matches all", " ", true, false},
+		{"missing reference", "@home", false, true},
+		{"reference is not expanded within expression", "@office && chan == 36", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := named.Compile(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("%s: error = %v, want error %t", tt.expr, err, tt.wantErr)
+			}
+			if err == nil && f.Match(testNetwork()) != tt.want {
+				t.Errorf("%s: got %t, want %t", tt.expr, !tt.want, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamedSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), configDir, configFilename)
+
+	named, err := LoadNamed(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(named) != 0 {
+		t.Fatalf("got %d filters from missing file, want 0", len(named))
+	}
+
+	if err = named.Set("@office", " chan == 36 "); err != nil {
+		t.Fatal(err)
+	}
+	if err = named.Set("bad", "foo == 1"); err == nil {
+		t.Error("expected error for invalid expression")
+	}
+	if err = named.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadNamed(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded["office"]; got != "chan == 36" || len(loaded) != 1 {
+		t.Errorf("loaded %v, want office: chan == 36", loaded)
+	}
+}
@@ -0,0 +1,258 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind uint8
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenOp     // comparison operator
+	tokenAnd    // &&
+	tokenOr     // ||
+	tokenNot    // !
+	tokenLParen // (
+	tokenRParen // )
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// Comparison operators, longest first.
+func operators() []string {
+	return []string{"==", "!=", "<=", ">=", "!~", "<", ">", "~"}
+}
+
+// Splits expression into tokens, positions are byte offsets of UTF-8 encoded expression.
+func lex(expr string) ([]token, error) {
+	tokens := []token{}
+
+	for pos := 0; pos < len(expr); {
+		c, size := utf8.DecodeRuneInString(expr[pos:])
+
+		switch {
+		case unicode.IsSpace(c):
+			pos += size
+
+		case strings.HasPrefix(expr[pos:], "&&"):
+			tokens = append(tokens, token{kind: tokenAnd, text: "&&", pos: pos})
+			pos += 2
+
+		case strings.HasPrefix(expr[pos:], "||"):
+			tokens = append(tokens, token{kind: tokenOr, text: "||", pos: pos})
+			pos += 2
+
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: pos})
+			pos++
+
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: pos})
+			pos++
+
+		case c == '"':
+			end := pos + 1
+			for end < len(expr) && expr[end] != '"' {
+				if expr[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(expr) {
+				return nil, fmt.Errorf("unterminated string at %d", pos)
+			}
+			text, err := strconv.Unquote(expr[pos : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at %d: %w", pos, err)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, pos: pos})
+			pos = end + 1
+
+		case c == '-' || c == '.' || (c >= '0' && c <= '9'):
+			end := pos + 1
+			for end < len(expr) && (expr[end] == '.' || (expr[end] >= '0' && expr[end] <= '9')) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: expr[pos:end], pos: pos})
+			pos = end
+
+		case c == '_' || unicode.IsLetter(c):
+			end := pos + size
+			for end < len(expr) {
+				r, n := utf8.DecodeRuneInString(expr[end:])
+				if !isIdentChar(r) {
+					break
+				}
+				end += n
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: expr[pos:end], pos: pos})
+			pos = end
+
+		default:
+			op := ""
+			for _, o := range operators() {
+				if strings.HasPrefix(expr[pos:], o) {
+					op = o
+					break
+				}
+			}
+
+			switch {
+			case len(op) > 0:
+				tokens = append(tokens, token{kind: tokenOp, text: op, pos: pos})
+				pos += len(op)
+			case c == '!':
+				tokens = append(tokens, token{kind: tokenNot, text: "!", pos: pos})
+				pos++
+			default:
+				return nil, fmt.Errorf("unexpected character '%c' at %d", c, pos)
+			}
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(expr)}), nil
+}
+
+func isIdentChar(c rune) bool {
+	return c == '_' || c == '-' || c == '.' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
+
+// Recursive descent parser of filter expression.
+//
+//	expr  = and { "||" and }
+//	and   = unary { "&&" unary }
+//	unary = "!" unary | "(" expr ")" | field op literal
+type parser struct {
+	tokens []token
+	pos    int
+	fields map[string]getter
+	alias  map[string]string
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) parse() (node, error) {
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected '%s' at %d", t.text, t.pos)
+	}
+
+	return n, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	t := p.next()
+
+	switch t.kind { //nolint:exhaustive // ignore
+	case tokenNot:
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{n}, nil
+
+	case tokenLParen:
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if r := p.next(); r.kind != tokenRParen {
+			return nil, fmt.Errorf("expected ')' at %d", r.pos)
+		}
+		return n, nil
+
+	case tokenIdent:
+		return p.parseComparison(t)
+
+	case tokenEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+
+	default:
+		return nil, fmt.Errorf("expected field name at %d, got '%s'", t.pos, t.text)
+	}
+}
+
+func (p *parser) parseComparison(field token) (node, error) {
+	name := strings.ToLower(field.text)
+	if alias, found := p.alias[name]; found {
+		name = alias
+	}
+
+	get, found := p.fields[name]
+	if !found {
+		return nil, fmt.Errorf("unknown field '%s' at %d", field.text, field.pos)
+	}
+
+	op := p.next()
+	if op.kind != tokenOp {
+		return nil, fmt.Errorf("expected operator after '%s' at %d", field.text, op.pos)
+	}
+
+	lit := p.next()
+	if lit.kind != tokenNumber && lit.kind != tokenString && lit.kind != tokenIdent {
+		return nil, fmt.Errorf("expected value after '%s' at %d", op.text, lit.pos)
+	}
+
+	return newCompareNode(get, op.text, lit)
+}
@@ -0,0 +1,166 @@
+package headless
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+	netdata "wfmon/pkg/data/net"
+	"wfmon/pkg/filter"
+	log "wfmon/pkg/logger"
+	"wfmon/pkg/repeater"
+)
+
+const (
+	DefaultInterval = time.Second
+)
+
+// Kind of emitted records.
+type Output uint8
+
+const (
+	OutputSnapshot Output = iota // all networks of the table on every interval
+	OutputFrames                 // every network observation received within interval
+)
+
+func (o Output) String() string {
+	return []string{
+		OutputSnapshot: "snapshot",
+		OutputFrames:   "frames",
+	}[o]
+}
+
+// Parses output kind, returns snapshot by default.
+func ParseOutput(s string) (Output, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", OutputSnapshot.String():
+		return OutputSnapshot, nil
+	case OutputFrames.String():
+		return OutputFrames, nil
+	default:
+		return OutputSnapshot, fmt.Errorf("unknown headless output '%s'", s)
+	}
+}
+
+// Source of network data for headless mode.
+type DataSource interface {
+	Networks() netdata.Slice
+	Subscribe(observer func(netdata.Network))
+}
+
+type Config struct {
+	Writer   io.Writer     // JSON lines destination, e.g. os.Stdout
+	Interval time.Duration // emit interval
+	Output   Output
+	Filter   *filter.Filter // emits only matched networks, all if nil
+}
+
+// Streams network data as JSON lines.
+type Serv struct {
+	ctx  context.Context
+	stop context.CancelFunc
+
+	dataSource DataSource
+	interval   time.Duration
+	output     Output
+	filter     *filter.Filter
+
+	writer  *bufio.Writer
+	encoder *json.Encoder
+
+	observed []netdata.Network
+	lock     sync.Mutex
+}
+
+func New(cfg *Config, dataSource DataSource) *Serv {
+	writer := bufio.NewWriter(cfg.Writer)
+
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	return &Serv{
+		dataSource: dataSource,
+		interval:   interval,
+		output:     cfg.Output,
+		filter:     cfg.Filter,
+		writer:     writer,
+		encoder:    json.NewEncoder(writer),
+	}
+}
+
+// Subscribes to network observations for frames output.
+func (s *Serv) Configure() error {
+	if s.output == OutputFrames {
+		s.dataSource.Subscribe(func(network netdata.Network) {
+			if !s.filter.Match(&network) {
+				return
+			}
+
+			s.lock.Lock()
+			defer s.lock.Unlock()
+
+			s.observed = append(s.observed, network)
+		})
+	}
+
+	return nil
+}
+
+// Emits records on every interval until shutdown.
+func (s *Serv) Start(ctx context.Context) error {
+	s.ctx, s.stop = context.WithCancel(ctx)
+
+	log.Infof("streaming %s records every %s", s.output, s.interval)
+	repeater.Default(s.ctx, s.interval, s.emit, s.emit)
+
+	return nil
+}
+
+func (s *Serv) Stop() error {
+	log.Info("stopping headless output")
+	if s.stop != nil {
+		s.stop()
+	}
+	return nil
+}
+
+// Flushes buffered output.
+func (s *Serv) Close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.writer.Flush(); err != nil {
+		log.Error(err)
+	}
+}
+
+// Writes records of the current interval.
+func (s *Serv) emit() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var networks netdata.Slice
+	switch s.output {
+	case OutputFrames:
+		networks, s.observed = s.observed, nil
+	default:
+		networks = s.filter.Apply(s.dataSource.Networks())
+	}
+
+	for i := range networks {
+		if err := s.encoder.Encode(networks[i].Record()); err != nil {
+			log.Errorf("failed to write record: %v", err)
+			return
+		}
+	}
+
+	if err := s.writer.Flush(); err != nil {
+		log.Errorf("failed to flush records: %v", err)
+	}
+}
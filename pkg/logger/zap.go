@@ -14,15 +14,52 @@ import (
 const (
 	callerSkipLevel = 1
 
-	logFilename   = "/usr/local/var/log/wfmon.log"
+	DefaultFilename = "/usr/local/var/log/wfmon.log"
+	DefaultLevel    = zapcore.InfoLevel
+	DebugLevel      = zapcore.DebugLevel
+
 	logMaxSize    = 500 // megabytes
 	logMaxBackups = 3
 	logMaxAge     = 28 // days
 )
 
+type config struct {
+	level    zapcore.Level
+	filename string
+}
+
+type Option func(*config)
+
+// Sets minimal level of file log entries.
+func WithLevel(level zapcore.Level) Option {
+	return func(o *config) {
+		o.level = level
+	}
+}
+
+// Sets log file path.
+func WithFilename(filename string) Option {
+	return func(o *config) {
+		o.filename = filename
+	}
+}
+
+// Parses log level, e.g. debug, info, warn or error.
+func ParseLevel(s string) (zapcore.Level, error) {
+	return zapcore.ParseLevel(s)
+}
+
 // Creates logger with config depending on application mode: dev / prod.
-func NewLogger(mode app.Mode) *zap.Logger {
-	core, options := getCore(mode)
+func NewLogger(mode app.Mode, opts ...Option) *zap.Logger {
+	o := config{
+		level:    DefaultLevel,
+		filename: DefaultFilename,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	core, options := getCore(mode, o)
 	logger := zap.New(core, options...)
 	// logger := zap.NewNop()
 
@@ -33,10 +70,10 @@ func NewSLogger(logger *zap.Logger) *zap.SugaredLogger {
 	return logger.Sugar()
 }
 
-func getDevCore() zapcore.Core {
+func getDevCore(o config) zapcore.Core {
 	// level-handling logic
 	highPriority := zap.NewAtomicLevelAt(zap.ErrorLevel)
-	lowPriority := zap.NewAtomicLevelAt(zap.DebugLevel)
+	lowPriority := zap.NewAtomicLevelAt(o.level)
 
 	// Encoder Configuration
 	encoderCfg := zap.NewDevelopmentEncoderConfig()
@@ -52,7 +89,7 @@ func getDevCore() zapcore.Core {
 	// output should also go to standard out.
 	stderr := zapcore.Lock(os.Stderr)
 	writer := zapcore.AddSync(&lumberjack.Logger{
-		Filename:   logFilename,
+		Filename:   o.filename,
 		MaxSize:    logMaxSize,
 		MaxBackups: logMaxBackups,
 		MaxAge:     logMaxAge,
@@ -65,10 +102,10 @@ func getDevCore() zapcore.Core {
 
 	return core
 }
-func getProdCore() zapcore.Core {
+func getProdCore(o config) zapcore.Core {
 	// level-handling logic
 	highPriority := zap.NewAtomicLevelAt(zap.ErrorLevel)
-	lowPriority := zap.NewAtomicLevelAt(zap.DebugLevel)
+	lowPriority := zap.NewAtomicLevelAt(o.level)
 
 	// Encoder Configuration
 	encoderCfg := zap.NewProductionEncoderConfig()
@@ -86,7 +123,7 @@ func getProdCore() zapcore.Core {
 	// consoleDebugging := zapcore.Lock(os.Stdout)
 	// consoleDebugging := zapcore.Lock(os.Stdout)
 	writer := zapcore.AddSync(&lumberjack.Logger{
-		Filename:   logFilename,
+		Filename:   o.filename,
 		MaxSize:    logMaxSize,
 		MaxBackups: logMaxBackups,
 		MaxAge:     logMaxAge,
@@ -100,15 +137,15 @@ func getProdCore() zapcore.Core {
 	return core
 }
 
-func getCore(mode app.Mode) (zapcore.Core, []zap.Option) {
+func getCore(mode app.Mode, o config) (zapcore.Core, []zap.Option) {
 	switch mode {
 	case app.Dev:
-		return getDevCore(), []zap.Option{
+		return getDevCore(o), []zap.Option{
 			zap.AddCaller(),
 			zap.AddCallerSkip(callerSkipLevel),
 		}
 	case app.Prod:
-		return getProdCore(), []zap.Option{}
+		return getProdCore(o), []zap.Option{}
 	default:
 		panic(fmt.Errorf("got unsupported application mode %s", mode))
 	}
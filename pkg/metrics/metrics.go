@@ -0,0 +1,193 @@
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+	netdata "wfmon/pkg/data/net"
+	"wfmon/pkg/filter"
+	log "wfmon/pkg/logger"
+	"wfmon/pkg/wifi"
+)
+
+const (
+	DefaultPath = "/metrics"
+
+	namespace         = "wfmon"
+	contentType       = "text/plain; version=0.0.4; charset=utf-8"
+	readHeaderTimeout = 5 * time.Second
+	shutdownTimeout   = 5 * time.Second
+)
+
+// Source of networks exposed as gauges.
+type DataSource interface {
+	Networks() netdata.Slice
+}
+
+// Source of packets counters.
+type MonitorStats interface {
+	Stats() wifi.MonitorStats
+}
+
+// Source of channel hops counter.
+type HopCounter interface {
+	Hops() uint64
+}
+
+type Config struct {
+	Addr    string         // listen address, e.g. :9100
+	Filter  *filter.Filter // exposes only matched networks, all if nil
+	Monitor MonitorStats   // packets counters, omitted if nil
+	Hopper  HopCounter     // hops counter, omitted if nil, e.g. on replay
+}
+
+// Exposes networks and process counters in Prometheus text format on /metrics.
+type Serv struct {
+	addr       string
+	dataSource DataSource
+	filter     *filter.Filter
+	monitor    MonitorStats
+	hopper     HopCounter
+
+	listener net.Listener
+	server   *http.Server
+}
+
+func New(cfg *Config, dataSource DataSource) *Serv {
+	s := &Serv{
+		addr:       cfg.Addr,
+		dataSource: dataSource,
+		filter:     cfg.Filter,
+		monitor:    cfg.Monitor,
+		hopper:     cfg.Hopper,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(DefaultPath, s.handle)
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	return s
+}
+
+// Listens on configured address, so that busy address fails before start.
+func (s *Serv) Configure() error {
+	var err error
+	if s.listener, err = net.Listen("tcp", s.addr); err != nil {
+		return fmt.Errorf("failed to listen metrics on '%s': %w", s.addr, err)
+	}
+
+	return nil
+}
+
+// Serves metrics until shutdown.
+func (s *Serv) Start(_ context.Context) error {
+	log.Infof("📈 serving metrics on %s%s", s.listener.Addr(), DefaultPath)
+
+	if err := s.server.Serve(s.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Gracefully shutdowns server waiting for active scrapes.
+func (s *Serv) Stop() error {
+	log.Info("stopping metrics server")
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	return s.server.Shutdown(ctx)
+}
+
+// Closes server and its listener.
+func (s *Serv) Close() {
+	if err := s.server.Close(); err != nil {
+		log.Error(err)
+	}
+}
+
+func (s *Serv) handle(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", contentType)
+
+	if err := s.Write(w); err != nil {
+		log.Errorf("failed to write metrics: %v", err)
+	}
+}
+
+// Writes all metrics in Prometheus text exposition format.
+func (s *Serv) Write(w io.Writer) error {
+	buf := bufio.NewWriter(w)
+
+	s.writeCounters(buf)
+	s.writeNetworks(buf)
+
+	return buf.Flush()
+}
+
+func (s *Serv) writeCounters(w *bufio.Writer) {
+	if s.monitor != nil {
+		stats := s.monitor.Stats()
+		writeCounter(w, "packets_read_total", "Packets read from interface or file.", stats.PacketsRead)
+		writeCounter(w, "frames_decoded_total", "Management and client frames decoded from packets.", stats.FramesDecoded)
+		writeCounter(w, "frames_dropped_total", "Packets dropped by capture before reading.", stats.FramesDropped)
+	}
+
+	if s.hopper != nil {
+		writeCounter(w, "channel_hops_total", "Channel hops performed on interface.", s.hopper.Hops())
+	}
+}
+
+func (s *Serv) writeNetworks(w *bufio.Writer) {
+	networks := s.filter.Apply(s.dataSource.Networks())
+
+	writeHeader(w, "networks", "gauge", "Networks discovered.")
+	fmt.Fprintf(w, "%s_networks %d\n", namespace, len(networks))
+
+	for _, gauge := range []struct {
+		name  string
+		help  string
+		value func(n *netdata.Network) int
+	}{
+		{"network_rssi_dbm", "Received signal strength of network.", func(n *netdata.Network) int { return int(n.RSSI) }},
+		{"network_noise_dbm", "Noise level of network channel.", func(n *netdata.Network) int { return int(n.Noise) }},
+		{"network_snr_db", "Signal to noise ratio of network.", func(n *netdata.Network) int { return int(n.SNR) }},
+		{"network_quality_percent", "Signal quality of network.", func(n *netdata.Network) int { return int(n.Quality) }},
+		{"network_channel", "Primary channel of network.", func(n *netdata.Network) int { return int(n.Channel) }},
+		{"network_channel_width_mhz", "Channel width of network.", func(n *netdata.Network) int { return int(n.ChannelWidth) }},
+		{"network_clients", "Client stations associated with network.", func(n *netdata.Network) int { return n.Clients }},
+	} {
+		writeHeader(w, gauge.name, "gauge", gauge.help)
+		for i := range networks {
+			fmt.Fprintf(w, "%s_%s{%s} %d\n", namespace, gauge.name, labels(&networks[i]), gauge.value(&networks[i]))
+		}
+	}
+}
+
+func writeCounter(w *bufio.Writer, name, help string, value uint64) {
+	writeHeader(w, name, "counter", help)
+	fmt.Fprintf(w, "%s_%s %d\n", namespace, name, value)
+}
+
+func writeHeader(w *bufio.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s_%s %s\n", namespace, name, help)
+	fmt.Fprintf(w, "# TYPE %s_%s %s\n", namespace, name, kind)
+}
+
+// Returns labels identifying network, e.g. ssid="home",bssid="aa:bb:cc:dd:ee:ff",band="5",vendor="Cisco".
+// Label values are escaped according to text exposition format.
+func labels(n *netdata.Network) string {
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+	return fmt.Sprintf(`ssid="%s",bssid="%s",band="%s",vendor="%s"`,
+		escaper.Replace(n.NetworkName), escaper.Replace(n.BSSID), n.Band.Range(), escaper.Replace(n.Manuf))
+}
@@ -14,6 +14,7 @@ const (
 	DefaultBufSize          = 2_097_152
 	DefaultPromisc          = true
 	DefaultTimeout          = pcap.BlockForever
+	DefaultFilter           = "" // no filter
 )
 
 // Packet capture options for pcap lib.
@@ -23,6 +24,7 @@ type CaptureOptions struct {
 	Bufsize int
 	Promisc bool
 	Timeout time.Duration
+	Filter  string // BPF filter expression, applied after activation
 }
 
 // Returns default packet capture options.
@@ -33,6 +35,7 @@ func DefaultOptions() CaptureOptions {
 		Bufsize: DefaultBufSize,
 		Promisc: DefaultPromisc,
 		Timeout: DefaultTimeout,
+		Filter:  DefaultFilter,
 	}
 }
 
@@ -67,7 +70,17 @@ func CaptureWithOptions(ifName string, options CaptureOptions) (*pcap.Handle, er
 		return nil, fmt.Errorf("error while setting timeout: %w", err)
 	}
 
-	return ihandle.Activate()
+	handle, err := ihandle.Activate()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = setFilter(handle, options.Filter); err != nil {
+		handle.Close()
+		return nil, err
+	}
+
+	return handle, nil
 }
 
 // Returns active pcap.Handle for interface with default capture options.
@@ -84,6 +97,36 @@ func CaptureWithTimeout(ifName string, timeout time.Duration) (*pcap.Handle, err
 	return CaptureWithOptions(ifName, opts)
 }
 
+// Returns offline pcap.Handle for pcap or pcapng file.
 func CaptureFromFile(file string) (*pcap.Handle, error) {
 	return pcap.OpenOffline(file)
 }
+
+// Returns offline pcap.Handle for pcap or pcapng file with given BPF filter.
+func CaptureFromFileWithFilter(file, filter string) (*pcap.Handle, error) {
+	handle, err := CaptureFromFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = setFilter(handle, filter); err != nil {
+		handle.Close()
+		return nil, err
+	}
+
+	return handle, nil
+}
+
+// Sets BPF filter on active handle, does nothing for empty filter.
+func setFilter(handle *pcap.Handle, filter string) error {
+	if len(filter) == 0 {
+		return nil
+	}
+
+	log.Debugf("setting BPF filter '%s'", filter)
+	if err := handle.SetBPFFilter(filter); err != nil {
+		return fmt.Errorf("error while setting BPF filter '%s': %w", filter, err)
+	}
+
+	return nil
+}
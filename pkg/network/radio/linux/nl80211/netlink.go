@@ -0,0 +1,262 @@
+//go:build linux
+
+package nl80211
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	genlVersion    = 1
+	receiveBufSize = 64 * 1024 // wiphy dump messages are large
+	nlaAlignTo     = 4
+	genlHeaderSize = 4 // struct genlmsghdr
+	nlaTypeMask    = ^uint16(unix.NLA_F_NESTED | unix.NLA_F_NET_BYTEORDER)
+)
+
+// Netlink attribute.
+type attribute struct {
+	Type uint16
+	Data []byte
+}
+
+func uint16Attr(t uint16, v uint16) attribute {
+	data := make([]byte, 2)
+	binary.NativeEndian.PutUint16(data, v)
+	return attribute{Type: t, Data: data}
+}
+
+func uint32Attr(t uint16, v uint32) attribute {
+	data := make([]byte, 4)
+	binary.NativeEndian.PutUint32(data, v)
+	return attribute{Type: t, Data: data}
+}
+
+// Null-terminated string attribute.
+func stringAttr(t uint16, s string) attribute {
+	return attribute{Type: t, Data: append([]byte(s), 0)}
+}
+
+// Flag attribute without payload.
+func flagAttr(t uint16) attribute {
+	return attribute{Type: t}
+}
+
+func (a attribute) Uint16() uint16 {
+	if len(a.Data) < 2 {
+		return 0
+	}
+	return binary.NativeEndian.Uint16(a.Data)
+}
+
+func (a attribute) Uint32() uint32 {
+	if len(a.Data) < 4 {
+		return 0
+	}
+	return binary.NativeEndian.Uint32(a.Data)
+}
+
+// Returns string without trailing null.
+func (a attribute) String() string {
+	for i, b := range a.Data {
+		if b == 0 {
+			return string(a.Data[:i])
+		}
+	}
+	return string(a.Data)
+}
+
+// Returns nested attributes.
+func (a attribute) Nested() ([]attribute, error) {
+	return decodeAttributes(a.Data)
+}
+
+func nlaAlign(n int) int {
+	return (n + nlaAlignTo - 1) & ^(nlaAlignTo - 1)
+}
+
+// Encodes attributes with alignment padding.
+func encodeAttributes(attrs ...attribute) []byte {
+	size := 0
+	for _, a := range attrs {
+		size += nlaAlign(unix.SizeofNlAttr + len(a.Data))
+	}
+
+	buf := make([]byte, size)
+	offset := 0
+	for _, a := range attrs {
+		binary.NativeEndian.PutUint16(buf[offset:], uint16(unix.SizeofNlAttr+len(a.Data)))
+		binary.NativeEndian.PutUint16(buf[offset+2:], a.Type)
+		copy(buf[offset+unix.SizeofNlAttr:], a.Data)
+		offset += nlaAlign(unix.SizeofNlAttr + len(a.Data))
+	}
+
+	return buf
+}
+
+// Decodes attributes, nested and byte order flags are dropped from attribute type.
+func decodeAttributes(b []byte) ([]attribute, error) {
+	attrs := []attribute{}
+	for len(b) >= unix.SizeofNlAttr {
+		length := int(binary.NativeEndian.Uint16(b))
+		if length < unix.SizeofNlAttr || length > len(b) {
+			return nil, fmt.Errorf("malformed netlink attribute length %d", length)
+		}
+
+		attrs = append(attrs, attribute{
+			Type: binary.NativeEndian.Uint16(b[2:]) & nlaTypeMask,
+			Data: b[unix.SizeofNlAttr:length],
+		})
+
+		b = b[min(nlaAlign(length), len(b)):]
+	}
+
+	return attrs, nil
+}
+
+// Generic netlink socket.
+type conn struct {
+	fd  int
+	pid uint32
+	seq uint32
+}
+
+// Opens generic netlink socket.
+func dial() (*conn, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_GENERIC)
+	if err != nil {
+		return nil, fmt.Errorf("error while opening netlink socket: %w", err)
+	}
+
+	if err = unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("error while binding netlink socket: %w", err)
+	}
+
+	sa, err := unix.Getsockname(fd)
+	if err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("error while getting netlink socket address: %w", err)
+	}
+
+	var pid uint32
+	if nl, ok := sa.(*unix.SockaddrNetlink); ok {
+		pid = nl.Pid
+	}
+
+	return &conn{fd: fd, pid: pid}, nil
+}
+
+func (c *conn) Close() error {
+	return unix.Close(c.fd)
+}
+
+// Resolves generic netlink family ID by name.
+func (c *conn) resolveFamily(name string) (uint16, error) {
+	msgs, err := c.execute(unix.GENL_ID_CTRL, unix.CTRL_CMD_GETFAMILY, 0,
+		stringAttr(unix.CTRL_ATTR_FAMILY_NAME, name))
+	if err != nil {
+		return 0, fmt.Errorf("error while resolving netlink family %s: %w", name, err)
+	}
+
+	for _, attrs := range msgs {
+		for _, a := range attrs {
+			if a.Type == unix.CTRL_ATTR_FAMILY_ID {
+				return a.Uint16(), nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("netlink family %s not found", name)
+}
+
+// Sends generic netlink request and returns attributes of every reply message.
+// Dump requests are read until done message, others are acknowledged.
+func (c *conn) execute(family uint16, cmd uint8, flags uint16, attrs ...attribute) ([][]attribute, error) {
+	c.seq++
+	seq := c.seq
+
+	dump := flags&unix.NLM_F_DUMP == unix.NLM_F_DUMP
+	if !dump {
+		flags |= unix.NLM_F_ACK
+	}
+
+	payload := encodeAttributes(attrs...)
+	msg := make([]byte, unix.SizeofNlMsghdr+genlHeaderSize, unix.SizeofNlMsghdr+genlHeaderSize+len(payload))
+	binary.NativeEndian.PutUint32(msg[0:], uint32(cap(msg)))
+	binary.NativeEndian.PutUint16(msg[4:], family)
+	binary.NativeEndian.PutUint16(msg[6:], unix.NLM_F_REQUEST|flags)
+	binary.NativeEndian.PutUint32(msg[8:], seq)
+	binary.NativeEndian.PutUint32(msg[12:], c.pid)
+	msg[unix.SizeofNlMsghdr] = cmd
+	msg[unix.SizeofNlMsghdr+1] = genlVersion
+	msg = append(msg, payload...)
+
+	if err := unix.Sendto(c.fd, msg, 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		return nil, fmt.Errorf("error while sending netlink message: %w", err)
+	}
+
+	replies := [][]attribute{}
+	buf := make([]byte, receiveBufSize)
+	for {
+		n, _, err := unix.Recvfrom(c.fd, buf, 0)
+		if err != nil {
+			return nil, fmt.Errorf("error while receiving netlink message: %w", err)
+		}
+
+		done, err := c.parse(buf[:n], seq, &replies)
+		if err != nil || done {
+			return replies, err
+		}
+	}
+}
+
+// Parses received datagram, appends reply attributes.
+// Returns true when done or acknowledgement message is received.
+func (c *conn) parse(b []byte, seq uint32, replies *[][]attribute) (bool, error) {
+	for len(b) >= unix.SizeofNlMsghdr {
+		length := int(binary.NativeEndian.Uint32(b[0:]))
+		msgType := binary.NativeEndian.Uint16(b[4:])
+		msgSeq := binary.NativeEndian.Uint32(b[8:])
+		if length < unix.SizeofNlMsghdr || length > len(b) {
+			return true, fmt.Errorf("malformed netlink message length %d", length)
+		}
+
+		body := b[unix.SizeofNlMsghdr:length]
+		b = b[min(nlaAlign(length), len(b)):]
+
+		// stale reply of previous request
+		if msgSeq != seq {
+			continue
+		}
+
+		switch msgType {
+		case unix.NLMSG_DONE:
+			return true, nil
+		case unix.NLMSG_ERROR:
+			if len(body) < 4 {
+				return true, fmt.Errorf("malformed netlink error message")
+			}
+			if errno := int32(binary.NativeEndian.Uint32(body)); errno != 0 {
+				return true, os.NewSyscallError("netlink", unix.Errno(-errno))
+			}
+			// acknowledgement
+			return true, nil
+		default:
+			if len(body) < genlHeaderSize {
+				continue
+			}
+			attrs, err := decodeAttributes(body[genlHeaderSize:])
+			if err != nil {
+				return true, err
+			}
+			*replies = append(*replies, attrs)
+		}
+	}
+
+	return false, nil
+}
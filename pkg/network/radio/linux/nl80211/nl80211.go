@@ -0,0 +1,336 @@
+//go:build linux
+
+// Package nl80211 implements a minimal nl80211 client over generic netlink.
+// https://wireless.wiki.kernel.org/en/developers/documentation/nl80211
+package nl80211
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	familyName        = "nl80211"
+	reasonDeauthLeave = 3  // sending station is leaving
+	maxIfaceNameLen   = 15 // IFNAMSIZ without null
+)
+
+// Interface types.
+const (
+	IfTypeStation = unix.NL80211_IFTYPE_STATION
+	IfTypeMonitor = unix.NL80211_IFTYPE_MONITOR
+)
+
+// Wireless interface.
+type Interface struct {
+	Index     int
+	Name      string
+	Wiphy     int // physical device index
+	Type      uint32
+	MAC       net.HardwareAddr
+	Frequency int // current operating frequency, MHz
+}
+
+// Basic service set an interface is associated with.
+type BSS struct {
+	BSSID     net.HardwareAddr
+	SSID      string
+	Frequency int // MHz
+}
+
+// Client of nl80211 family.
+type Client struct {
+	c      *conn
+	family uint16
+}
+
+// Opens generic netlink socket and resolves nl80211 family.
+func New() (*Client, error) {
+	c, err := dial()
+	if err != nil {
+		return nil, err
+	}
+
+	family, err := c.resolveFamily(familyName)
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	return &Client{c: c, family: family}, nil
+}
+
+func (c *Client) Close() error {
+	return c.c.Close()
+}
+
+// Returns all wireless interfaces.
+func (c *Client) Interfaces() ([]Interface, error) {
+	msgs, err := c.c.execute(c.family, unix.NL80211_CMD_GET_INTERFACE, unix.NLM_F_DUMP)
+	if err != nil {
+		return nil, fmt.Errorf("error while listing wireless interfaces: %w", err)
+	}
+
+	ifaces := make([]Interface, 0, len(msgs))
+	for _, attrs := range msgs {
+		ifaces = append(ifaces, parseInterface(attrs))
+	}
+
+	return ifaces, nil
+}
+
+// Returns BSS the interface is associated with.
+func (c *Client) BSS(ifindex int) (*BSS, error) {
+	msgs, err := c.c.execute(c.family, unix.NL80211_CMD_GET_SCAN, unix.NLM_F_DUMP,
+		uint32Attr(unix.NL80211_ATTR_IFINDEX, uint32(ifindex)))
+	if err != nil {
+		return nil, fmt.Errorf("error while getting scan results: %w", err)
+	}
+
+	for _, attrs := range msgs {
+		for _, a := range attrs {
+			if a.Type != unix.NL80211_ATTR_BSS {
+				continue
+			}
+
+			nested, err := a.Nested()
+			if err != nil {
+				return nil, err
+			}
+
+			if bss, ok := parseAssociatedBSS(nested); ok {
+				return bss, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("interface %d is not associated", ifindex)
+}
+
+// Disconnects interface from network, does nothing if not connected.
+func (c *Client) Disconnect(ifindex int) error {
+	_, err := c.c.execute(c.family, unix.NL80211_CMD_DISCONNECT, 0,
+		uint32Attr(unix.NL80211_ATTR_IFINDEX, uint32(ifindex)),
+		uint16Attr(unix.NL80211_ATTR_REASON_CODE, reasonDeauthLeave))
+	if errors.Is(err, unix.ENOTCONN) {
+		return nil
+	}
+
+	return err
+}
+
+// Returns enabled frequencies of physical device, MHz.
+func (c *Client) Frequencies(wiphy int) ([]int, error) {
+	msgs, err := c.c.execute(c.family, unix.NL80211_CMD_GET_WIPHY, unix.NLM_F_DUMP,
+		uint32Attr(unix.NL80211_ATTR_WIPHY, uint32(wiphy)),
+		flagAttr(unix.NL80211_ATTR_SPLIT_WIPHY_DUMP))
+	if err != nil {
+		return nil, fmt.Errorf("error while getting wiphy %d: %w", wiphy, err)
+	}
+
+	freqs := []int{}
+	for _, attrs := range msgs {
+		for _, a := range attrs {
+			if a.Type != unix.NL80211_ATTR_WIPHY_BANDS {
+				continue
+			}
+
+			bandFreqs, err := parseBands(a)
+			if err != nil {
+				return nil, err
+			}
+			freqs = append(freqs, bandFreqs...)
+		}
+	}
+
+	return freqs, nil
+}
+
+// Sets operating frequency of interface, MHz.
+func (c *Client) SetFrequency(ifindex, freq int) error {
+	_, err := c.c.execute(c.family, unix.NL80211_CMD_SET_WIPHY, 0,
+		uint32Attr(unix.NL80211_ATTR_IFINDEX, uint32(ifindex)),
+		uint32Attr(unix.NL80211_ATTR_WIPHY_FREQ, uint32(freq)),
+		uint32Attr(unix.NL80211_ATTR_WIPHY_CHANNEL_TYPE, unix.NL80211_CHAN_NO_HT))
+	if err != nil {
+		return fmt.Errorf("error while setting frequency %d on interface %d: %w", freq, ifindex, err)
+	}
+
+	return nil
+}
+
+// Creates monitor mode virtual interface on physical device and brings it up.
+func (c *Client) NewMonitorInterface(wiphy int, name string) (*Interface, error) {
+	if len(name) > maxIfaceNameLen {
+		name = name[:maxIfaceNameLen]
+	}
+
+	msgs, err := c.c.execute(c.family, unix.NL80211_CMD_NEW_INTERFACE, 0,
+		uint32Attr(unix.NL80211_ATTR_WIPHY, uint32(wiphy)),
+		stringAttr(unix.NL80211_ATTR_IFNAME, name),
+		uint32Attr(unix.NL80211_ATTR_IFTYPE, IfTypeMonitor))
+	if err != nil {
+		return nil, fmt.Errorf("error while creating monitor interface %s: %w", name, err)
+	}
+
+	iface := Interface{Name: name, Wiphy: wiphy, Type: IfTypeMonitor}
+	if len(msgs) > 0 {
+		iface = parseInterface(msgs[0])
+	}
+
+	if err = setLinkUp(iface.Name); err != nil {
+		return nil, err
+	}
+
+	return &iface, nil
+}
+
+func parseInterface(attrs []attribute) Interface {
+	var iface Interface
+	for _, a := range attrs {
+		switch a.Type {
+		case unix.NL80211_ATTR_IFINDEX:
+			iface.Index = int(a.Uint32())
+		case unix.NL80211_ATTR_IFNAME:
+			iface.Name = a.String()
+		case unix.NL80211_ATTR_WIPHY:
+			iface.Wiphy = int(a.Uint32())
+		case unix.NL80211_ATTR_IFTYPE:
+			iface.Type = a.Uint32()
+		case unix.NL80211_ATTR_MAC:
+			iface.MAC = net.HardwareAddr(a.Data)
+		case unix.NL80211_ATTR_WIPHY_FREQ:
+			iface.Frequency = int(a.Uint32())
+		}
+	}
+
+	return iface
+}
+
+// Returns BSS if it has associated or joined status.
+func parseAssociatedBSS(attrs []attribute) (*BSS, bool) {
+	var (
+		bss        BSS
+		associated bool
+	)
+
+	for _, a := range attrs {
+		switch a.Type {
+		case unix.NL80211_BSS_BSSID:
+			bss.BSSID = net.HardwareAddr(a.Data)
+		case unix.NL80211_BSS_FREQUENCY:
+			bss.Frequency = int(a.Uint32())
+		case unix.NL80211_BSS_INFORMATION_ELEMENTS:
+			bss.SSID = parseSSID(a.Data)
+		case unix.NL80211_BSS_STATUS:
+			status := a.Uint32()
+			associated = status == unix.NL80211_BSS_STATUS_ASSOCIATED || status == unix.NL80211_BSS_STATUS_IBSS_JOINED
+		}
+	}
+
+	return &bss, associated
+}
+
+// Returns SSID from information elements.
+func parseSSID(ies []byte) string {
+	const ssidElementID = 0
+
+	for len(ies) >= 2 {
+		id, length := ies[0], int(ies[1])
+		if len(ies) < 2+length {
+			break
+		}
+		if id == ssidElementID {
+			return string(ies[2 : 2+length])
+		}
+		ies = ies[2+length:]
+	}
+
+	return ""
+}
+
+// Returns enabled frequencies of bands attribute.
+func parseBands(bandsAttr attribute) ([]int, error) {
+	bands, err := bandsAttr.Nested()
+	if err != nil {
+		return nil, err
+	}
+
+	freqs := []int{}
+	for _, band := range bands {
+		bandAttrs, err := band.Nested()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range bandAttrs {
+			if a.Type != unix.NL80211_BAND_ATTR_FREQS {
+				continue
+			}
+
+			freqAttrs, err := a.Nested()
+			if err != nil {
+				return nil, err
+			}
+
+			for _, f := range freqAttrs {
+				if freq, ok := parseFrequency(f); ok {
+					freqs = append(freqs, freq)
+				}
+			}
+		}
+	}
+
+	return freqs, nil
+}
+
+// Returns frequency if it is not disabled.
+func parseFrequency(freqAttr attribute) (int, bool) {
+	attrs, err := freqAttr.Nested()
+	if err != nil {
+		return 0, false
+	}
+
+	var (
+		freq     int
+		disabled bool
+	)
+	for _, a := range attrs {
+		switch a.Type {
+		case unix.NL80211_FREQUENCY_ATTR_FREQ:
+			freq = int(a.Uint32())
+		case unix.NL80211_FREQUENCY_ATTR_DISABLED:
+			disabled = true
+		}
+	}
+
+	return freq, freq > 0 && !disabled
+}
+
+// Sets IFF_UP flag on network interface.
+func setLinkUp(name string) error {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return fmt.Errorf("error while opening socket: %w", err)
+	}
+	defer unix.Close(fd)
+
+	ifr, err := unix.NewIfreq(name)
+	if err != nil {
+		return err
+	}
+
+	if err = unix.IoctlIfreq(fd, unix.SIOCGIFFLAGS, ifr); err != nil {
+		return fmt.Errorf("error while getting interface %s flags: %w", name, err)
+	}
+
+	ifr.SetUint16(ifr.Uint16() | unix.IFF_UP)
+	if err = unix.IoctlIfreq(fd, unix.SIOCSIFFLAGS, ifr); err != nil {
+		return fmt.Errorf("error while bringing interface %s up: %w", name, err)
+	}
+
+	return nil
+}
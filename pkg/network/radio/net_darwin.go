@@ -33,3 +33,9 @@ func GetSupportedChannels(ifaceName string) ([]int, error) {
 func SetInterfaceChannel(ifaceName string, channel int) error {
 	return corewlan.SetInterfaceChannel(ifaceName, channel)
 }
+
+// Returns name of monitor mode interface for given interface.
+// CoreWLAN sets monitor mode on the same interface.
+func CreateMonitorInterface(ifaceName string) (string, error) {
+	return ifaceName, nil
+}
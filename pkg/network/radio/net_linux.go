@@ -1,22 +1,247 @@
-//go:build !windows | !darwin
+//go:build linux
 
 package radionet
 
-// Disconnects interface from network. Required before for setting interface in monitoring mode.
+import (
+	"fmt"
+	"math"
+	"wfmon/pkg/network"
+	"wfmon/pkg/network/radio/linux/nl80211"
+	"wfmon/pkg/wifi/chanfreq"
+)
+
+const monitorIfaceSuffix = "mon"
+
+// Wireless operations required by Linux backend.
+// Implemented by nl80211.Client, can be faked in tests.
+type Backend interface {
+	Interfaces() ([]nl80211.Interface, error)
+	BSS(ifindex int) (*nl80211.BSS, error)
+	Disconnect(ifindex int) error
+	Frequencies(wiphy int) ([]int, error)
+	SetFrequency(ifindex, freq int) error
+	NewMonitorInterface(wiphy int, name string) (*nl80211.Interface, error)
+	Close() error
+}
+
+// Radio operations on top of wireless backend.
+type Radio struct {
+	backend Backend
+}
+
+func NewRadio(backend Backend) *Radio {
+	return &Radio{backend: backend}
+}
+
+// Returns first station interface, or any wireless interface if there is no station.
+func (r *Radio) GetDefaultWiFiInterface() (string, error) {
+	ifaces, err := r.backend.Interfaces()
+	if err != nil {
+		return "", err
+	}
+
+	for _, iface := range ifaces {
+		if iface.Type == nl80211.IfTypeStation {
+			return iface.Name, nil
+		}
+	}
+
+	if len(ifaces) > 0 {
+		return ifaces[0].Name, nil
+	}
+
+	return "", fmt.Errorf("no wireless interface found")
+}
+
+// Returns network associated with the given interface.
+func (r *Radio) GetAssociatedNetwork(ifaceName string) (network.Network, error) {
+	iface, err := r.interfaceByName(ifaceName)
+	if err != nil {
+		return network.Network{}, err
+	}
+
+	bss, err := r.backend.BSS(iface.Index)
+	if err != nil {
+		return network.Network{}, err
+	}
+
+	return network.Network{
+		SSID:    bss.SSID,
+		BSSID:   bss.BSSID.String(),
+		Channel: chanfreq.ToChannel(bss.Frequency),
+	}, nil
+}
+
+// Disconnects interface from network.
+func (r *Radio) DisassociateFromNetwork(ifaceName string) error {
+	iface, err := r.interfaceByName(ifaceName)
+	if err != nil {
+		return err
+	}
+
+	return r.backend.Disconnect(iface.Index)
+}
+
+// Returns 2.4GHz and 5GHz channels supported by physical device of given interface.
+// Channels are kept only if @SetInterfaceChannel tunes them back to the same frequency:
+// 6GHz channel numbers overlap with 2.4GHz and 5GHz ones, 4.9GHz channels are not tunable by number.
+func (r *Radio) GetSupportedChannels(ifaceName string) ([]int, error) {
+	iface, err := r.interfaceByName(ifaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	freqs, err := r.backend.Frequencies(iface.Wiphy)
+	if err != nil {
+		return nil, err
+	}
+
+	channels := make([]int, 0, len(freqs))
+	for _, freq := range freqs {
+		if channel := int(chanfreq.ToChannel(freq)); channel > 0 && legacyFrequency(channel) == freq {
+			channels = append(channels, channel)
+		}
+	}
+
+	return channels, nil
+}
+
+// Changes radio channel on monitor interface of the same physical device, or on given interface.
+func (r *Radio) SetInterfaceChannel(ifaceName string, channel int) error {
+	iface, err := r.interfaceByName(ifaceName)
+	if err != nil {
+		return err
+	}
+
+	freq := legacyFrequency(channel)
+	if freq == 0 {
+		return fmt.Errorf("unknown channel %d", channel)
+	}
+
+	if mon, ok := r.monitorInterface(iface.Wiphy); ok {
+		iface = mon
+	}
+
+	return r.backend.SetFrequency(iface.Index, freq)
+}
+
+// Returns frequency of 2.4GHz or 5GHz channel number, zero for unknown channel.
+func legacyFrequency(channel int) int {
+	if channel <= 0 || channel > math.MaxUint8 {
+		return 0
+	}
+
+	return chanfreq.ToFrequency(uint8(channel), chanfreq.LegacyRange(uint8(channel)))
+}
+
+// Returns monitor interface on the same physical device, creates one if missing.
+func (r *Radio) CreateMonitorInterface(ifaceName string) (string, error) {
+	iface, err := r.interfaceByName(ifaceName)
+	if err != nil {
+		return "", err
+	}
+
+	if iface.Type == nl80211.IfTypeMonitor {
+		return iface.Name, nil
+	}
+
+	if mon, ok := r.monitorInterface(iface.Wiphy); ok {
+		return mon.Name, nil
+	}
+
+	mon, err := r.backend.NewMonitorInterface(iface.Wiphy, iface.Name+monitorIfaceSuffix)
+	if err != nil {
+		return "", err
+	}
+
+	return mon.Name, nil
+}
+
+func (r *Radio) interfaceByName(ifaceName string) (nl80211.Interface, error) {
+	ifaces, err := r.backend.Interfaces()
+	if err != nil {
+		return nl80211.Interface{}, err
+	}
+
+	for _, iface := range ifaces {
+		if iface.Name == ifaceName {
+			return iface, nil
+		}
+	}
+
+	return nl80211.Interface{}, fmt.Errorf("no wireless interface '%s' found", ifaceName)
+}
+
+func (r *Radio) monitorInterface(wiphy int) (nl80211.Interface, bool) {
+	ifaces, err := r.backend.Interfaces()
+	if err != nil {
+		return nl80211.Interface{}, false
+	}
+
+	for _, iface := range ifaces {
+		if iface.Wiphy == wiphy && iface.Type == nl80211.IfTypeMonitor {
+			return iface, true
+		}
+	}
+
+	return nl80211.Interface{}, false
+}
+
+// Runs operation with nl80211 backend.
+func withRadio[T any](fnc func(r *Radio) (T, error)) (T, error) {
+	client, err := nl80211.New()
+	if err != nil {
+		var empty T
+		return empty, err
+	}
+	defer client.Close()
+
+	return fnc(NewRadio(client))
+}
+
+// Returns default WiFi interface name.
+func GetDefaultWiFiInterface() (string, error) {
+	return withRadio(func(r *Radio) (string, error) {
+		return r.GetDefaultWiFiInterface()
+	})
+}
+
+// Returns network associated with the given interface.
+// Should be invoked before setting interface in monitoring mode.
+func GetAssociatedNetwork(ifaceName string) (network.Network, error) {
+	return withRadio(func(r *Radio) (network.Network, error) {
+		return r.GetAssociatedNetwork(ifaceName)
+	})
+}
+
+// Disconnects interface from network.
+// Should be invoked before setting interface in monitoring mode.
 func DisassociateFromNetwork(ifaceName string) error {
-	log.Error("Unimplemented")
+	_, err := withRadio(func(r *Radio) (struct{}, error) {
+		return struct{}{}, r.DisassociateFromNetwork(ifaceName)
+	})
+	return err
 }
 
 // Returns channels supported by given interface.
 func GetSupportedChannels(ifaceName string) ([]int, error) {
-	log.Error("Unimplemented")
-}
-
-func GetAssociatedNetwork(ifaceName string) (Network, error) {
-	log.Error("Unimplemented")
+	return withRadio(func(r *Radio) ([]int, error) {
+		return r.GetSupportedChannels(ifaceName)
+	})
 }
 
 // Change radio channel on given interface.
 func SetInterfaceChannel(ifaceName string, channel int) error {
-	log.Error("Unimplemented")
+	_, err := withRadio(func(r *Radio) (struct{}, error) {
+		return struct{}{}, r.SetInterfaceChannel(ifaceName, channel)
+	})
+	return err
+}
+
+// Returns name of monitor mode interface for given interface.
+// Creates monitor virtual interface on the same physical device if missing.
+func CreateMonitorInterface(ifaceName string) (string, error) {
+	return withRadio(func(r *Radio) (string, error) {
+		return r.CreateMonitorInterface(ifaceName)
+	})
 }
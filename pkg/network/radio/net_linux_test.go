@@ -0,0 +1,160 @@
+//go:build linux
+
+package radionet
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"wfmon/pkg/network/radio/linux/nl80211"
+)
+
+// Fake wireless backend keeping interfaces in memory.
+type fakeBackend struct {
+	ifaces  []nl80211.Interface
+	freqs   map[int][]int // by wiphy
+	tuned   map[int]int   // frequency by interface index
+	created []string      // names of created monitor interfaces
+}
+
+func (b *fakeBackend) Interfaces() ([]nl80211.Interface, error) {
+	return b.ifaces, nil
+}
+
+func (b *fakeBackend) BSS(int) (*nl80211.BSS, error) {
+	return nil, errors.New("not associated")
+}
+
+func (b *fakeBackend) Disconnect(int) error {
+	return nil
+}
+
+func (b *fakeBackend) Frequencies(wiphy int) ([]int, error) {
+	return b.freqs[wiphy], nil
+}
+
+func (b *fakeBackend) SetFrequency(ifindex, freq int) error {
+	if b.tuned == nil {
+		b.tuned = map[int]int{}
+	}
+	b.tuned[ifindex] = freq
+	return nil
+}
+
+func (b *fakeBackend) NewMonitorInterface(wiphy int, name string) (*nl80211.Interface, error) {
+	iface := nl80211.Interface{Index: len(b.ifaces) + 1, Name: name, Wiphy: wiphy, Type: nl80211.IfTypeMonitor}
+	b.ifaces = append(b.ifaces, iface)
+	b.created = append(b.created, name)
+	return &iface, nil
+}
+
+func (b *fakeBackend) Close() error {
+	return nil
+}
+
+func station(index int, name string, wiphy int) nl80211.Interface {
+	return nl80211.Interface{Index: index, Name: name, Wiphy: wiphy, Type: nl80211.IfTypeStation}
+}
+
+func monitor(index int, name string, wiphy int) nl80211.Interface {
+	return nl80211.Interface{Index: index, Name: name, Wiphy: wiphy, Type: nl80211.IfTypeMonitor}
+}
+
+func TestGetDefaultWiFiInterface(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifaces  []nl80211.Interface
+		want    string
+		wantErr bool
+	}{
+		{"station preferred", []nl80211.Interface{monitor(1, "mon0", 0), station(2, "wlan0", 0)}, "wlan0", false},
+		{"any interface without station", []nl80211.Interface{monitor(1, "mon0", 0)}, "mon0", false},
+		{"no interface", nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRadio(&fakeBackend{ifaces: tt.ifaces}).GetDefaultWiFiInterface()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %t", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateMonitorInterface(t *testing.T) {
+	tests := []struct {
+		name        string
+		ifaces      []nl80211.Interface
+		iface       string
+		want        string
+		wantCreated []string
+	}{
+		{"monitor given", []nl80211.Interface{monitor(1, "mon0", 0)}, "mon0", "mon0", nil},
+		{"monitor reused", []nl80211.Interface{station(1, "wlan0", 0), monitor(2, "mon0", 0)}, "wlan0", "mon0", nil},
+		{"monitor of another device is not reused",
+			[]nl80211.Interface{station(1, "wlan0", 0), monitor(2, "mon1", 1)}, "wlan0", "wlan0mon", []string{"wlan0mon"}},
+		{"monitor created", []nl80211.Interface{station(1, "wlan0", 0)}, "wlan0", "wlan0mon", []string{"wlan0mon"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &fakeBackend{ifaces: tt.ifaces}
+			got, err := NewRadio(backend).CreateMonitorInterface(tt.iface)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(backend.created, tt.wantCreated) {
+				t.Errorf("created %v, want %v", backend.created, tt.wantCreated)
+			}
+		})
+	}
+
+	if _, err := NewRadio(&fakeBackend{}).CreateMonitorInterface("wlan0"); err == nil {
+		t.Error("expected error for unknown interface")
+	}
+}
+
+func TestGetSupportedChannels(t *testing.T) {
+	backend := &fakeBackend{
+		ifaces: []nl80211.Interface{station(1, "wlan0", 0)},
+		freqs: map[int][]int{0: {
+			2412, 2437, 2484, // 2.4GHz channels 1, 6 and 14
+			4920, 4940, // 4.9GHz channels 184 and 188 are not tunable by number
+			5180, 5825, // 5GHz channels 36 and 165
+			5935, 5955, 6115, // 6GHz channels 2, 1 and 33 overlap with 2.4GHz and 5GHz
+		}},
+	}
+
+	got, err := NewRadio(backend).GetSupportedChannels("wlan0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []int{1, 6, 14, 36, 165}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSetInterfaceChannel(t *testing.T) {
+	backend := &fakeBackend{ifaces: []nl80211.Interface{station(1, "wlan0", 0), monitor(2, "mon0", 0)}}
+	radio := NewRadio(backend)
+
+	if err := radio.SetInterfaceChannel("wlan0", 36); err != nil {
+		t.Fatal(err)
+	}
+	if got := backend.tuned[2]; got != 5180 {
+		t.Errorf("monitor tuned to %d, want 5180", got)
+	}
+
+	if err := radio.SetInterfaceChannel("wlan0", 188); err == nil {
+		t.Error("expected error for unknown channel")
+	}
+}
@@ -2,21 +2,41 @@
 
 package radionet
 
+import (
+	"errors"
+	"wfmon/pkg/network"
+)
+
+func errUnimplemented() error {
+	return errors.New("unimplemented on windows")
+}
+
+// Returns default WiFi interface name.
+func GetDefaultWiFiInterface() (string, error) {
+	return "", errUnimplemented()
+}
+
+// Returns network associated with the given interface.
+func GetAssociatedNetwork(ifaceName string) (network.Network, error) {
+	return network.Network{}, errUnimplemented()
+}
+
 // Disconnects interface from network. Required before for setting interface in monitoring mode.
 func DisassociateFromNetwork(ifaceName string) error {
-	log.Error("Unimplemented")
+	return errUnimplemented()
 }
 
 // Returns channels supported by given interface.
 func GetSupportedChannels(ifaceName string) ([]int, error) {
-	log.Error("Unimplemented")
-}
-
-func GetAssociatedNetwork(ifaceName string) (Network, error) {
-	log.Error("Unimplemented")
+	return nil, errUnimplemented()
 }
 
 // Change radio channel on given interface.
 func SetInterfaceChannel(ifaceName string, channel int) error {
-	log.Error("Unimplemented")
+	return errUnimplemented()
+}
+
+// Returns name of monitor mode interface for given interface.
+func CreateMonitorInterface(ifaceName string) (string, error) {
+	return ifaceName, nil
 }
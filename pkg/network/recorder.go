@@ -0,0 +1,158 @@
+package network
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	log "wfmon/pkg/logger"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+)
+
+const (
+	recordFileExt        = ".pcapng"
+	recordFileTimeLayout = "20060102T150405"
+)
+
+// Packet recorder options.
+type RecorderConfig struct {
+	Path        string        // file path prefix, e.g. /tmp/wfmon gives /tmp/wfmon-20240102T150405-000.pcapng
+	MaxSize     int64         // rotate file after given size in bytes, zero for no limit
+	MaxDuration time.Duration // rotate file after given capture duration, zero for no limit
+}
+
+// Returns true if recording is configured.
+func (cfg RecorderConfig) Enabled() bool {
+	return len(cfg.Path) > 0
+}
+
+// Writes packets to pcapng files with rotation by size or duration.
+// Recorded files can be loaded by @CaptureFromFile.
+// Safe for concurrent use: packets are written by monitor while it might be closed on shutdown.
+type Recorder struct {
+	cfg      RecorderConfig
+	linkType layers.LinkType
+
+	lock    sync.Mutex
+	closed  bool // packets written after close are dropped
+	seq     int
+	file    *os.File
+	writer  *pcapgo.NgWriter
+	counter *countingWriter
+	started time.Time // capture time of the first packet in current file
+}
+
+func NewRecorder(cfg RecorderConfig, linkType layers.LinkType) *Recorder {
+	return &Recorder{
+		cfg:      cfg,
+		linkType: linkType,
+	}
+}
+
+// Writes packet to current file, rotates file if limits are exceeded.
+// Packets written after close are dropped.
+func (r *Recorder) Write(ci gopacket.CaptureInfo, data []byte) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.closed {
+		return nil
+	}
+
+	if r.writer == nil || r.exceeded(ci.Timestamp) {
+		if err := r.rotate(ci.Timestamp); err != nil {
+			return err
+		}
+	}
+
+	if err := r.writer.WritePacket(ci, data); err != nil {
+		return fmt.Errorf("error while writing packet to %s: %w", r.file.Name(), err)
+	}
+
+	return nil
+}
+
+// Flushes and closes current file, further packets are not recorded.
+func (r *Recorder) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.closed = true
+
+	return r.closeFile()
+}
+
+// Flushes and closes current file.
+func (r *Recorder) closeFile() error {
+	if r.writer == nil {
+		return nil
+	}
+
+	defer func() {
+		r.file, r.writer, r.counter = nil, nil, nil
+	}()
+
+	if err := r.writer.Flush(); err != nil {
+		r.file.Close()
+		return fmt.Errorf("error while flushing %s: %w", r.file.Name(), err)
+	}
+
+	return r.file.Close()
+}
+
+func (r *Recorder) exceeded(timestamp time.Time) bool {
+	if r.cfg.MaxSize > 0 && r.counter.written >= r.cfg.MaxSize {
+		return true
+	}
+
+	return r.cfg.MaxDuration > 0 && timestamp.Sub(r.started) >= r.cfg.MaxDuration
+}
+
+// Closes current file and opens the next one.
+func (r *Recorder) rotate(timestamp time.Time) error {
+	if err := r.closeFile(); err != nil {
+		log.Warn(err)
+	}
+
+	name := fmt.Sprintf("%s-%s-%03d%s",
+		strings.TrimSuffix(r.cfg.Path, recordFileExt),
+		time.Now().Format(recordFileTimeLayout),
+		r.seq,
+		recordFileExt,
+	)
+	r.seq++
+
+	file, err := os.Create(name)
+	if err != nil {
+		return fmt.Errorf("error while creating record file: %w", err)
+	}
+
+	counter := &countingWriter{file: file}
+	writer, err := pcapgo.NewNgWriter(counter, r.linkType)
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("error while writing pcapng header to %s: %w", name, err)
+	}
+
+	log.Infof("recording packets to %s", name)
+
+	r.file, r.writer, r.counter, r.started = file, writer, counter, timestamp
+
+	return nil
+}
+
+// Counts bytes written to file.
+type countingWriter struct {
+	file    *os.File
+	written int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.file.Write(p)
+	w.written += int64(n)
+	return n, err
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 	log "wfmon/pkg/logger"
 	radionet "wfmon/pkg/network/radio"
@@ -23,13 +25,16 @@ type ChannelHopperServ struct {
 
 	idx         int
 	channels    []int
+	allowed     []int
 	hopInterval time.Duration
 	chLock      sync.RWMutex
+	hops        atomic.Uint64
 }
 
 type ChannelHopperConfig struct {
 	IFace       *net.Interface
 	HopInterval time.Duration
+	Channels    []int // channels to hop, all supported if empty
 }
 
 func NewChannelHopperServ(cfg *ChannelHopperConfig) *ChannelHopperServ {
@@ -37,16 +42,34 @@ func NewChannelHopperServ(cfg *ChannelHopperConfig) *ChannelHopperServ {
 		iface:       cfg.IFace,
 		idx:         0,
 		hopInterval: cfg.HopInterval,
+		allowed:     cfg.Channels,
 	}
 }
 
 // Loads supported channels from configured interface.
+// Keeps only configured channels if provided.
 func (h *ChannelHopperServ) Configure() error {
 	var err error
 	log.Infof("Loading supported channel on '%s'", h.iface.Name)
-	h.channels, err = radionet.GetSupportedChannels(h.iface.Name)
+	if h.channels, err = radionet.GetSupportedChannels(h.iface.Name); err != nil {
+		return err
+	}
+
+	if len(h.allowed) == 0 {
+		return nil
+	}
+
+	channels := make([]int, 0, len(h.allowed))
+	for _, channel := range h.allowed {
+		if slices.Contains(h.channels, channel) {
+			channels = append(channels, channel)
+		} else {
+			log.Warnf("channel %d is not supported by '%s'", channel, h.iface.Name)
+		}
+	}
+	h.channels = channels
 
-	return err
+	return nil
 }
 
 func (h *ChannelHopperServ) Close() {
@@ -63,7 +86,17 @@ func (h *ChannelHopperServ) hop() error {
 	}
 
 	log.Debugf("Interface %s hopping to channel %d", h.iface.Name, h.channels[h.idx])
-	return radionet.SetInterfaceChannel(h.iface.Name, h.channels[h.idx])
+	if err := radionet.SetInterfaceChannel(h.iface.Name, h.channels[h.idx]); err != nil {
+		return err
+	}
+
+	h.hops.Add(1)
+	return nil
+}
+
+// Returns number of channel hops performed.
+func (h *ChannelHopperServ) Hops() uint64 {
+	return h.hops.Load()
 }
 
 // Returns current channel number.
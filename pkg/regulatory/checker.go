@@ -0,0 +1,184 @@
+package regulatory
+
+import (
+	"fmt"
+	"strings"
+	netdata "wfmon/pkg/data/net"
+	"wfmon/pkg/wifi"
+)
+
+const (
+	channelStep = 4 // channel numbers of adjacent 20MHz channels in 5GHz and 6GHz bands
+	// 80MHz center is 8 channels away of 160MHz center in VHT operation with two centers
+	segmentsDistance = 8
+)
+
+// Flags networks whose channel or width is not permitted in regulatory domain,
+// or whose advertised country differs from configured or neighbours' one.
+type Checker struct {
+	domain Domain // configured domain, empty to check against advertised domains
+}
+
+// Returns checker against domain of given country, advertised domains are used if country is empty.
+func NewChecker(country string) (*Checker, error) {
+	c := &Checker{}
+	if len(country) == 0 {
+		return c, nil
+	}
+
+	domain, found := Lookup(country)
+	if !found {
+		return nil, fmt.Errorf("unknown regulatory domain '%s', expected one of %s",
+			country, strings.Join(Countries(), ", "))
+	}
+	c.domain = domain
+
+	return c, nil
+}
+
+// Returns configured country, empty if advertised domains are checked.
+func (c *Checker) Country() string {
+	return c.domain.Country
+}
+
+// Sets regulatory issues of networks.
+// Networks are neighbours, the most advertised country is expected from all of them unless domain is configured.
+func (c *Checker) Check(networks netdata.Slice) {
+	common := commonCountry(networks)
+
+	for i := range networks {
+		n := &networks[i]
+		n.RegIssues = nil
+
+		// configured country is expected instead of neighbours' one
+		country := strings.ToUpper(n.Country.Country)
+		switch {
+		case len(country) == 0:
+		case len(c.domain.Country) > 0 && country != c.domain.Country:
+			n.RegIssues = append(n.RegIssues,
+				fmt.Sprintf("country %s differs from configured %s", country, c.domain.Country))
+		case len(c.domain.Country) == 0 && country != common:
+			n.RegIssues = append(n.RegIssues,
+				fmt.Sprintf("country %s differs from neighbours' %s", country, common))
+		}
+
+		domain, found := c.domain, len(c.domain.Country) > 0
+		if !found {
+			domain, found = FromCountryIE(n.Country)
+		}
+		if found {
+			n.RegIssues = append(n.RegIssues, checkChannel(n, &domain)...)
+		}
+	}
+}
+
+// Returns issues of primary channel and 20MHz channels occupied by network.
+func checkChannel(n *netdata.Network, domain *Domain) []string {
+	bandRange := n.Band.Range()
+	if len(bandRange) == 0 || n.Channel == 0 || !domain.Knows(bandRange) {
+		return nil
+	}
+
+	rule, permitted := domain.Rule(bandRange, n.Channel)
+	if !permitted {
+		return []string{fmt.Sprintf("channel %d is not permitted in %s", n.Channel, domain.Country)}
+	}
+
+	channels := Subchannels(n)
+	width := max(n.ChannelWidth, uint16(len(channels)*20)) //nolint:gomnd // ignore
+	if width > rule.MaxWidth {
+		return []string{fmt.Sprintf("width %dMHz exceeds %dMHz permitted on channel %d in %s",
+			width, rule.MaxWidth, n.Channel, domain.Country)}
+	}
+
+	for _, ch := range channels {
+		if _, permitted := domain.Rule(bandRange, ch); !permitted {
+			return []string{fmt.Sprintf("width %dMHz spans channel %d not permitted in %s",
+				width, ch, domain.Country)}
+		}
+	}
+
+	return nil
+}
+
+// Returns 20MHz channels occupied by network: primary, secondary and channels of VHT segments.
+func Subchannels(n *netdata.Network) []uint8 {
+	// channel numbers are computed in int, out of range ones of malformed elements are dropped
+	appendChannel := func(channels []uint8, ch int) []uint8 {
+		if ch > 0 && ch <= 255 { //nolint:gomnd // ignore
+			channels = append(channels, uint8(ch))
+		}
+		return channels
+	}
+
+	// channel numbers of secondary 20MHz channel differ by 4 in every band
+	secondary := func() []uint8 {
+		primary := int(n.Channel)
+		//nolint:exhaustive // ignore
+		switch n.Offset {
+		case wifi.SCA:
+			return appendChannel([]uint8{n.Channel}, primary+channelStep)
+		case wifi.SCB:
+			return append(appendChannel(nil, primary-channelStep), n.Channel)
+		default:
+			return []uint8{n.Channel}
+		}
+	}
+
+	// 20MHz channels around center of segment of given width
+	segment := func(center uint8, width uint16) []uint8 {
+		count := int(width / 20) //nolint:gomnd // ignore
+		first := int(center) - (count-1)*channelStep/2
+		channels := make([]uint8, 0, count)
+		for i := 0; i < count; i++ {
+			channels = appendChannel(channels, first+i*channelStep)
+		}
+		return channels
+	}
+
+	c0, c1 := n.FrequencyCenter0, n.FrequencyCenter1
+	if n.Band == wifi.ISM || c0 == 0 {
+		return secondary()
+	}
+
+	//nolint:exhaustive // ignore
+	switch n.WidthOperation {
+	case wifi.WidthOperation80:
+		// 160MHz and 80+80MHz are signaled by second center in 80MHz operation
+		switch {
+		case c1 == 0:
+			return segment(c0, 80) //nolint:gomnd // ignore
+		case c1-c0 == segmentsDistance || c0-c1 == segmentsDistance:
+			return segment(c1, 160) //nolint:gomnd // ignore
+		default:
+			return append(segment(c0, 80), segment(c1, 80)...) //nolint:gomnd // ignore
+		}
+	case wifi.WidthOperation160:
+		return segment(c0, 160) //nolint:gomnd // ignore
+	case wifi.WidthOperation80And80:
+		return append(segment(c0, 80), segment(c1, 80)...) //nolint:gomnd // ignore
+	case wifi.WidthOperation320:
+		return segment(c0, 320) //nolint:gomnd // ignore
+	default:
+		return secondary()
+	}
+}
+
+// Returns country advertised by most networks, the first one in alphabetical order on tie.
+func commonCountry(networks netdata.Slice) string {
+	counts := map[string]int{}
+	for i := range networks {
+		if country := strings.ToUpper(networks[i].Country.Country); len(country) > 0 {
+			counts[country]++
+		}
+	}
+
+	var common string
+	for country, cnt := range counts {
+		if cnt > counts[common] || (cnt == counts[common] && country < common) {
+			common = country
+		}
+	}
+
+	return common
+}
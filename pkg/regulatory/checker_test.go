@@ -0,0 +1,84 @@
+package regulatory
+
+import (
+	"reflect"
+	"testing"
+	netdata "wfmon/pkg/data/net"
+	"wfmon/pkg/wifi"
+)
+
+func TestSubchannels(t *testing.T) {
+	tests := []struct {
+		name    string
+		network netdata.Network
+		want    []uint8
+	}{
+		{"20MHz", netdata.Network{Band: wifi.ISM, Channel: 6}, []uint8{6}},
+		{"secondary above", netdata.Network{Band: wifi.ISM, Channel: 1, Offset: wifi.SCA}, []uint8{1, 5}},
+		{"secondary below", netdata.Network{Band: wifi.ISM, Channel: 6, Offset: wifi.SCB}, []uint8{2, 6}},
+		{"secondary below channel 1 is dropped", netdata.Network{Band: wifi.ISM, Channel: 1, Offset: wifi.SCB}, []uint8{1}},
+		{"secondary below channel 3 is dropped", netdata.Network{Band: wifi.ISM, Channel: 3, Offset: wifi.SCB}, []uint8{3}},
+		{"secondary below 6GHz channel 1 is dropped", netdata.Network{Band: wifi.UNII5, Channel: 1, Offset: wifi.SCB}, []uint8{1}},
+		{"secondary above channel 253 is dropped", netdata.Network{Band: wifi.UNII3, Channel: 253, Offset: wifi.SCA}, []uint8{253}},
+		{"2.4GHz ignores segment centers", netdata.Network{Band: wifi.ISM, Channel: 6, FrequencyCenter0: 42}, []uint8{6}},
+		{"80MHz", netdata.Network{Band: wifi.UNII1, Channel: 36, FrequencyCenter0: 42, WidthOperation: wifi.WidthOperation80},
+			[]uint8{36, 40, 44, 48}},
+		{"160MHz by second center", netdata.Network{Band: wifi.UNII1, Channel: 36, FrequencyCenter0: 42, FrequencyCenter1: 50,
+			WidthOperation: wifi.WidthOperation80}, []uint8{36, 40, 44, 48, 52, 56, 60, 64}},
+		{"80+80MHz by second center", netdata.Network{Band: wifi.UNII1, Channel: 36, FrequencyCenter0: 42, FrequencyCenter1: 106,
+			WidthOperation: wifi.WidthOperation80}, []uint8{36, 40, 44, 48, 100, 104, 108, 112}},
+		{"160MHz", netdata.Network{Band: wifi.UNII1, Channel: 36, FrequencyCenter0: 50, WidthOperation: wifi.WidthOperation160},
+			[]uint8{36, 40, 44, 48, 52, 56, 60, 64}},
+		{"320MHz", netdata.Network{Band: wifi.UNII5, Channel: 1, FrequencyCenter0: 31, WidthOperation: wifi.WidthOperation320},
+			[]uint8{1, 5, 9, 13, 17, 21, 25, 29, 33, 37, 41, 45, 49, 53, 57, 61}},
+		{"malformed center drops channels below 1", netdata.Network{Band: wifi.UNII5, Channel: 1, FrequencyCenter0: 2,
+			WidthOperation: wifi.WidthOperation80}, []uint8{4, 8}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Subchannels(&tt.network); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		country string
+		network netdata.Network
+		want    []string
+	}{
+		{"permitted", "DE", netdata.Network{Band: wifi.ISM, Channel: 6, ChannelWidth: 20}, nil},
+		{"40MHz below channel 2", "DE", netdata.Network{Band: wifi.ISM, Channel: 2, ChannelWidth: 40, Offset: wifi.SCB}, nil},
+		{"channel not permitted", "US", netdata.Network{Band: wifi.ISM, Channel: 13, ChannelWidth: 20},
+			[]string{"channel 13 is not permitted in US"}},
+		{"width exceeds", "DE", netdata.Network{Band: wifi.ISM, Channel: 6, ChannelWidth: 80},
+			[]string{"width 80MHz exceeds 40MHz permitted on channel 6 in DE"}},
+		{"spans channel not permitted", "US", netdata.Network{Band: wifi.ISM, Channel: 9, ChannelWidth: 40, Offset: wifi.SCA},
+			[]string{"width 40MHz spans channel 13 not permitted in US"}},
+		{"advertised country differs", "DE", netdata.Network{Band: wifi.ISM, Channel: 6, ChannelWidth: 20,
+			Country: wifi.CountryIE{Country: "FR"}}, []string{"country FR differs from configured DE"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker, err := NewChecker(tt.country)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			networks := netdata.Slice{tt.network}
+			checker.Check(networks)
+			if got := networks[0].RegIssues; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewChecker("XX"); err == nil {
+		t.Error("expected error for unknown country")
+	}
+}
@@ -0,0 +1,171 @@
+package regulatory
+
+import (
+	"slices"
+	"strings"
+	"wfmon/pkg/wifi"
+)
+
+// Range of 20MHz channels permitted in band with maximum channel width.
+type Rule struct {
+	Band         string // band range as of wifi.Band: 2.4, 5 or 6
+	FirstChannel uint8
+	LastChannel  uint8
+	MaxWidth     uint16 // MHz
+}
+
+// Regulatory domain: channels permitted in country.
+type Domain struct {
+	Country string
+	Rules   []Rule
+	partial bool // bands without rules are unknown rather than not permitted
+}
+
+// Returns true if channels of band are known in domain.
+func (d *Domain) Knows(bandRange string) bool {
+	if !d.partial {
+		return true
+	}
+
+	for _, r := range d.Rules {
+		if r.Band == bandRange {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns rule permitting channel in band, false if channel is not permitted.
+func (d *Domain) Rule(bandRange string, channel uint8) (Rule, bool) {
+	for _, r := range d.Rules {
+		if r.Band == bandRange && channel >= r.FirstChannel && channel <= r.LastChannel {
+			return r, true
+		}
+	}
+
+	return Rule{}, false
+}
+
+// Returns domain advertised in Country element.
+// Channels of 2.4GHz and 5GHz bands are taken from subband triplets,
+// channels of other bands and widths are taken from known domain of the country.
+func FromCountryIE(ie wifi.CountryIE) (Domain, bool) {
+	known, found := Lookup(ie.Country)
+	if len(ie.Triplets) == 0 {
+		return known, found
+	}
+
+	domain := Domain{Country: strings.ToUpper(ie.Country), partial: !found}
+	for _, t := range ie.Triplets {
+		bandRange := t.Band().Range()
+		if len(bandRange) == 0 {
+			continue
+		}
+
+		// triplets do not limit width, the widest one of the band is permitted
+		var maxWidth uint16
+		for _, r := range known.Rules {
+			if r.Band == bandRange {
+				maxWidth = max(maxWidth, r.MaxWidth)
+			}
+		}
+		if maxWidth == 0 {
+			maxWidth = defaultMaxWidth(bandRange)
+		}
+
+		// channels of 5GHz triplet are 4 apart, all 20MHz channels are permitted in between
+		domain.Rules = append(domain.Rules, Rule{
+			Band:         bandRange,
+			FirstChannel: t.FirstChannel,
+			LastChannel:  t.LastChannel(),
+			MaxWidth:     maxWidth,
+		})
+	}
+
+	// keep bands not covered by triplets, e.g. 6GHz
+	for _, r := range known.Rules {
+		if !ie.Covers(r.Band) {
+			domain.Rules = append(domain.Rules, r)
+		}
+	}
+
+	return domain, true
+}
+
+// Returns the widest channel of band, MHz.
+func defaultMaxWidth(bandRange string) uint16 {
+	//nolint:gomnd // ignore
+	switch bandRange {
+	case "2.4":
+		return 40
+	case "6":
+		return 320
+	default:
+		return 160
+	}
+}
+
+// Returns known regulatory domain by country code.
+func Lookup(country string) (Domain, bool) {
+	country = strings.ToUpper(country)
+
+	for _, d := range Domains() {
+		if d.Country == country {
+			return d, true
+		}
+	}
+
+	return Domain{}, false
+}
+
+// Returns sorted codes of known countries.
+func Countries() []string {
+	domains := Domains()
+	codes := make([]string, len(domains))
+	for i := range domains {
+		codes[i] = domains[i].Country
+	}
+	slices.Sort(codes)
+
+	return codes
+}
+
+// Returns known regulatory domains.
+// Channel plans are simplified from wireless-regdb: DFS, power limits and indoor restrictions are ignored.
+//
+//nolint:gomnd // ignore
+func Domains() []Domain {
+	var (
+		ism11 = Rule{"2.4", 1, 11, 40}
+		ism13 = Rule{"2.4", 1, 13, 40}
+		unii1 = Rule{"5", 36, 64, 160}   // U-NII-1 and U-NII-2A
+		unii2 = Rule{"5", 100, 144, 160} // U-NII-2C
+		etsi2 = Rule{"5", 100, 140, 160} // U-NII-2C without channel 144
+		unii3 = Rule{"5", 149, 165, 80}  // U-NII-3
+		fcc3  = Rule{"5", 149, 177, 160} // U-NII-3 and U-NII-4
+		lpi6  = Rule{"6", 1, 93, 320}    // U-NII-5
+		full6 = Rule{"6", 1, 233, 320}   // U-NII-5 to U-NII-8
+	)
+
+	etsi := func(country string) Domain {
+		return Domain{Country: country, Rules: []Rule{ism13, unii1, etsi2, lpi6}}
+	}
+
+	return []Domain{
+		{Country: "US", Rules: []Rule{ism11, unii1, unii2, fcc3, full6}},
+		{Country: "CA", Rules: []Rule{ism11, unii1, unii2, unii3, full6}},
+		{Country: "BR", Rules: []Rule{ism13, unii1, etsi2, unii3, full6}},
+		{Country: "KR", Rules: []Rule{ism13, unii1, unii2, unii3, full6}},
+		{Country: "JP", Rules: []Rule{ism13, {"2.4", 14, 14, 20}, unii1, unii2, lpi6}},
+		{Country: "CN", Rules: []Rule{ism13, unii1, unii3}},
+		{Country: "IN", Rules: []Rule{ism13, unii1, unii2, unii3}},
+		{Country: "AU", Rules: []Rule{ism13, unii1, {"5", 100, 116, 80}, {"5", 132, 144, 80}, unii3, lpi6}},
+		{Country: "NZ", Rules: []Rule{ism13, unii1, {"5", 100, 116, 80}, {"5", 132, 144, 80}, unii3, lpi6}},
+		{Country: "GB", Rules: []Rule{ism13, unii1, etsi2, unii3, lpi6}},
+		{Country: "CH", Rules: []Rule{ism13, unii1, etsi2, lpi6}},
+		{Country: "NO", Rules: []Rule{ism13, unii1, etsi2, lpi6}},
+		etsi("AT"), etsi("BE"), etsi("CZ"), etsi("DE"), etsi("DK"), etsi("ES"), etsi("FI"), etsi("FR"),
+		etsi("GR"), etsi("HU"), etsi("IE"), etsi("IT"), etsi("NL"), etsi("PL"), etsi("PT"), etsi("SE"),
+	}
+}
@@ -0,0 +1,53 @@
+package regulatory
+
+import (
+	"reflect"
+	"testing"
+	"wfmon/pkg/wifi"
+)
+
+func triplet(first, num uint8, power int8) wifi.CountryTriplet {
+	return wifi.CountryTriplet{FirstChannel: first, NumChannels: num, MaxPower: power}
+}
+
+func TestFromCountryIE(t *testing.T) {
+	tests := []struct {
+		name  string
+		ie    wifi.CountryIE
+		want  Domain
+		found bool
+	}{
+		{"known country without triplets", wifi.CountryIE{Country: "ch"},
+			Domain{Country: "CH", Rules: []Rule{{"2.4", 1, 13, 40}, {"5", 36, 64, 160}, {"5", 100, 140, 160}, {"6", 1, 93, 320}}}, true},
+		{"unknown country without triplets", wifi.CountryIE{Country: "XX"}, Domain{}, false},
+		{"triplets replace bands of known country", wifi.CountryIE{Country: "DE",
+			Triplets: []wifi.CountryTriplet{triplet(1, 13, 20), triplet(36, 4, 23)}},
+			Domain{Country: "DE", Rules: []Rule{{"2.4", 1, 13, 40}, {"5", 36, 48, 160}, {"6", 1, 93, 320}}}, true},
+		{"triplets of unknown country are partial", wifi.CountryIE{Country: "xx",
+			Triplets: []wifi.CountryTriplet{triplet(1, 11, 20)}},
+			Domain{Country: "XX", Rules: []Rule{{"2.4", 1, 11, 40}}, partial: true}, true},
+		{"triplet out of known bands is ignored", wifi.CountryIE{Country: "XX",
+			Triplets: []wifi.CountryTriplet{triplet(20, 4, 20), triplet(149, 5, 30)}},
+			Domain{Country: "XX", Rules: []Rule{{"5", 149, 165, 160}}, partial: true}, true},
+		{"malformed triplet ends with the last channel", wifi.CountryIE{Country: "XX",
+			Triplets: []wifi.CountryTriplet{triplet(149, 100, 30)}},
+			Domain{Country: "XX", Rules: []Rule{{"5", 149, 255, 160}}, partial: true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := FromCountryIE(tt.ie)
+			if found != tt.found {
+				t.Fatalf("found = %t, want %t", found, tt.found)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	partial, _ := FromCountryIE(wifi.CountryIE{Country: "XX", Triplets: []wifi.CountryTriplet{triplet(1, 11, 20)}})
+	if !partial.Knows("2.4") || partial.Knows("5") {
+		t.Error("partial domain should know advertised bands only")
+	}
+}
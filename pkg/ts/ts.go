@@ -1,6 +1,7 @@
 package ts
 
 import (
+	"math"
 	"time"
 	"wfmon/pkg/utils/vec"
 )
@@ -30,13 +31,11 @@ func New(maxLen uint) TimeSeries {
 func (ts TimeSeries) Add(val float64, timestamp time.Time) TimeSeries {
 	sample := Sample{
 		Value:     val,
-		Timestamp: time.Now(),
+		Timestamp: timestamp,
 	}
 	ts.Samples = append(ts.Samples, sample)
 
-	ts.Shrink()
-
-	return ts
+	return ts.Shrink()
 }
 
 func (ts TimeSeries) Shrink() TimeSeries {
@@ -97,3 +96,66 @@ func (ts TimeSeries) RangeAndMap(cnt int, modifier func(val float64) float64) Ve
 func (v Vector) Reverse() Vector {
 	return vec.Reverse(v)
 }
+
+// Aggregates of time series values.
+type Stats struct {
+	Min, Avg, Max float64
+	Count         int
+}
+
+// Returns min, average and max of samples.
+func (ts TimeSeries) Stats() Stats {
+	if len(ts.Samples) == 0 {
+		return Stats{}
+	}
+
+	stats := Stats{
+		Min:   ts.Samples[0].Value,
+		Max:   ts.Samples[0].Value,
+		Count: len(ts.Samples),
+	}
+
+	sum := 0.0
+	for _, sample := range ts.Samples {
+		stats.Min = math.Min(stats.Min, sample.Value)
+		stats.Max = math.Max(stats.Max, sample.Value)
+		sum += sample.Value
+	}
+	stats.Avg = sum / float64(stats.Count)
+
+	return stats
+}
+
+// Running aggregates of all values added, unlike time series limited to the latest samples.
+type Summary struct {
+	min, max, sum float64
+	count         int
+}
+
+// Returns summary with value added.
+func (s Summary) Add(val float64) Summary {
+	if s.count == 0 {
+		s.min, s.max = val, val
+	}
+
+	s.min = math.Min(s.min, val)
+	s.max = math.Max(s.max, val)
+	s.sum += val
+	s.count++
+
+	return s
+}
+
+// Returns min, average and max of all values added.
+func (s Summary) Stats() Stats {
+	if s.count == 0 {
+		return Stats{}
+	}
+
+	return Stats{
+		Min:   s.min,
+		Avg:   s.sum / float64(s.count),
+		Max:   s.max,
+		Count: s.count,
+	}
+}
@@ -1,5 +1,10 @@
 package conv
 
+import (
+	"strconv"
+	"time"
+)
+
 func BoolToInt(b bool) int {
 	var i int
 	if b {
@@ -7,3 +12,21 @@ func BoolToInt(b bool) int {
 	}
 	return i
 }
+
+// Returns short presentation of duration in the largest unit, e.g. 5s, 3m, 2h or 1d.
+func FormatAge(d time.Duration) string {
+	const day = 24 * time.Hour
+
+	switch {
+	case d < 0:
+		return "0s"
+	case d < time.Minute:
+		return strconv.Itoa(int(d/time.Second)) + "s"
+	case d < time.Hour:
+		return strconv.Itoa(int(d/time.Minute)) + "m"
+	case d < day:
+		return strconv.Itoa(int(d/time.Hour)) + "h"
+	default:
+		return strconv.Itoa(int(d/day)) + "d"
+	}
+}
@@ -0,0 +1,119 @@
+package clients
+
+import (
+	"strconv"
+	"time"
+	netdata "wfmon/pkg/data/net"
+	"wfmon/pkg/ds"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	defaultWidth           = 95
+	defaultHeight          = 10
+	defaultRefreshInterval = time.Second
+)
+
+var (
+	headerStyle   = lipgloss.NewStyle().Bold(true)
+	faintStyle    = lipgloss.NewStyle().Faint(true)
+	selectedStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#77dd77")) // green
+)
+
+// Source of client stations.
+type DataSource interface {
+	ds.ClientProvider
+}
+
+type emptyDataSource struct{}
+
+func (emptyDataSource) Clients() netdata.ClientSlice {
+	return netdata.ClientSlice{}
+}
+
+// Table of client stations, clients of highlighted network go first.
+type Model struct {
+	width   int
+	height  int
+	focused bool
+	content string
+
+	netKey     netdata.Key
+	clients    netdata.ClientSlice
+	dataSource DataSource
+}
+
+type Option func(*Model)
+
+func WithDataSource(dataSource DataSource) Option {
+	return func(m *Model) {
+		m.SetDataSource(dataSource)
+	}
+}
+
+func WithFocused(focus bool) Option {
+	return func(m *Model) {
+		m.Focused(focus)
+	}
+}
+
+func WithHeight(h int) Option {
+	return func(m *Model) {
+		m.height = h
+	}
+}
+
+func New(opts ...Option) *Model {
+	m := &Model{
+		width:      defaultWidth,
+		height:     defaultHeight,
+		focused:    true,
+		dataSource: emptyDataSource{},
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+func (m *Model) SetDataSource(dataSource DataSource) {
+	m.dataSource = dataSource
+}
+
+func (m *Model) SetNetworkKey(key netdata.Key) {
+	m.netKey = key
+}
+
+func (m *Model) SetWidth(w int) {
+	m.width = w
+}
+
+func (m *Model) Width() int {
+	return m.width
+}
+
+func (m *Model) Focused(focus bool) {
+	m.focused = focus
+}
+
+func (m *Model) GetFocused() bool {
+	return m.focused
+}
+
+// Returns title with number of all clients and clients of highlighted network.
+func (m *Model) Title() string {
+	title := "Clients " + strconv.Itoa(len(m.clients))
+	if n := m.countOf(m.netKey.BSSID); n > 0 {
+		title += " / " + strconv.Itoa(n) + " on " + m.netKey.BSSID
+	}
+
+	return title
+}
+
+// Views table rendered by @refresh.
+func (m *Model) View() string {
+	return m.content
+}
@@ -0,0 +1,147 @@
+package clients
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	netdata "wfmon/pkg/data/net"
+	"wfmon/pkg/ds"
+	"wfmon/pkg/utils/conv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type refreshMsg time.Time
+
+// Invokes refresh table by interval.
+// Fresh data obtained on timer end.
+func refreshTick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return refreshMsg(t)
+	})
+}
+
+// Handles refresh tick.
+// Fetches clients from data source.
+// Renders table.
+func (m *Model) onRefreshMsg(_ refreshMsg) {
+	m.clients = m.dataSource.Clients()
+
+	m.refresh()
+}
+
+// Returns number of clients associated with BSSID.
+func (m *Model) countOf(bssid string) int {
+	if len(bssid) == 0 {
+		return 0
+	}
+
+	cnt := 0
+	for i := range m.clients {
+		if m.clients[i].BSSID == bssid {
+			cnt++
+		}
+	}
+
+	return cnt
+}
+
+// Returns current time to calculate time since clients were seen.
+func (m *Model) now() time.Time {
+	if clock, ok := m.dataSource.(ds.Clock); ok {
+		return clock.Now()
+	}
+
+	var latest time.Time
+	for i := range m.clients {
+		if m.clients[i].Timestamp.After(latest) {
+			latest = m.clients[i].Timestamp
+		}
+	}
+
+	return latest
+}
+
+// Sorts clients of highlighted network first, then associated ones, then recently seen.
+func (m *Model) sort() {
+	rank := func(c *netdata.Client) int {
+		switch {
+		case c.Associated() && c.BSSID == m.netKey.BSSID:
+			return 0
+		case c.Associated():
+			return 1
+		default:
+			return 2 //nolint:gomnd // ignore
+		}
+	}
+
+	slices.SortFunc(m.clients, func(a, b netdata.Client) int {
+		if r := rank(&a) - rank(&b); r != 0 {
+			return r
+		}
+		if c := b.Timestamp.Compare(a.Timestamp); c != 0 {
+			return c
+		}
+		return strings.Compare(a.MAC, b.MAC)
+	})
+}
+
+// Immediately renders table of clients fitting the width and height.
+func (m *Model) refresh() {
+	if !m.focused {
+		return
+	}
+
+	if len(m.clients) == 0 {
+		m.content = faintStyle.Render("no clients discovered")
+		return
+	}
+
+	m.sort()
+	now := m.now()
+
+	const format = "%-17s  %-8.8s  %-17s  %4s  %4s  %s"
+	header := fmt.Sprintf(format, "Client", "Vendor", "AP", "RSSI", "Seen", "Probed")
+	rows := []string{headerStyle.MaxWidth(m.width).Render(header)}
+
+	// header and line of hidden clients take two lines
+	limit := min(len(m.clients), m.height-1)
+	if limit < len(m.clients) {
+		limit--
+	}
+
+	for i := 0; i < limit; i++ {
+		c := &m.clients[i]
+
+		rssi := "-"
+		if c.RSSI != 0 {
+			rssi = strconv.Itoa(int(c.RSSI))
+		}
+
+		line := fmt.Sprintf(format,
+			c.MAC,
+			c.Manuf,
+			c.BSSID,
+			rssi,
+			conv.FormatAge(now.Sub(c.Timestamp)),
+			strings.Join(c.ProbedSSIDs, ", "),
+		)
+		style := lipgloss.NewStyle()
+		switch {
+		case c.Associated() && c.BSSID == m.netKey.BSSID:
+			style = selectedStyle
+		case !c.Associated():
+			style = faintStyle
+		}
+		rows = append(rows, style.MaxWidth(m.width).Render(line))
+	}
+
+	if hidden := len(m.clients) - limit; hidden > 0 {
+		rows = append(rows, faintStyle.Render(fmt.Sprintf("+%d more", hidden)))
+	}
+
+	m.content = lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
@@ -6,7 +6,9 @@ import (
 	log "wfmon/pkg/logger"
 	"wfmon/pkg/utils/cmp"
 	"wfmon/pkg/widgets"
+	"wfmon/pkg/widgets/clients"
 	"wfmon/pkg/widgets/events"
+	"wfmon/pkg/widgets/info"
 	"wfmon/pkg/widgets/sparkline"
 	"wfmon/pkg/widgets/spectrum"
 	"wfmon/pkg/widgets/wifitable"
@@ -26,12 +28,37 @@ var (
 	}()
 )
 
+// Replay controls of packets source.
+type Replayer interface {
+	TogglePause()
+	Step()
+	Faster()
+	Slower()
+	String() string
+}
+
+// Writes current networks to a file.
+type Exporter interface {
+	Export() (string, error)
+}
+
+// Event with result of networks export.
+type exportedMsg struct {
+	path string
+	err  error
+}
+
 type Model struct {
 	dataSource ds.Provider
+	replay     Replayer
+	exporter   Exporter
+	status     string
 	width      int
 	table      *wifitable.Model
 	sparkline  *sparkline.Model
 	spectrum   *spectrum.Model
+	info       *info.Model
+	clients    *clients.Model
 	chart      tea.Model
 	keys       KeyMap
 	help       *help.Model
@@ -47,6 +74,22 @@ func WithDataSource(dataSource ds.Provider) Option {
 		m.table.SetDataSource(dataSource)
 		m.sparkline.SetDataSource(dataSource)
 		m.spectrum.SetDataSource(dataSource)
+		m.info.SetDataSource(dataSource)
+		if clientsSource, ok := dataSource.(clients.DataSource); ok {
+			m.clients.SetDataSource(clientsSource)
+		}
+	}
+}
+
+func WithReplay(r Replayer) Option {
+	return func(m *Model) {
+		m.replay = r
+	}
+}
+
+func WithExporter(e Exporter) Option {
+	return func(m *Model) {
+		m.exporter = e
 	}
 }
 
@@ -68,6 +111,18 @@ func WithSpectrum(s *spectrum.Model) Option {
 	}
 }
 
+func WithInfo(i *info.Model) Option {
+	return func(m *Model) {
+		m.info = i
+	}
+}
+
+func WithClients(c *clients.Model) Option {
+	return func(m *Model) {
+		m.clients = c
+	}
+}
+
 func New(opts ...Option) *Model {
 	help := help.New()
 	help.ShowAll = true
@@ -76,6 +131,8 @@ func New(opts ...Option) *Model {
 		table:     wifitable.New(),
 		sparkline: sparkline.New(),
 		spectrum:  spectrum.New(),
+		info:      info.New(info.WithFocused(false)),
+		clients:   clients.New(clients.WithFocused(false)),
 		help:      &help,
 		keys:      NewKeyMap(),
 	}
@@ -86,6 +143,8 @@ func New(opts ...Option) *Model {
 
 	m.width = m.table.Width()
 	m.chart = m.sparkline
+	m.keys.SetReplayEnabled(m.replay != nil)
+	m.keys.Export.SetEnabled(m.exporter != nil)
 
 	return m
 }
@@ -94,6 +153,8 @@ func (m *Model) Init() tea.Cmd {
 	return tea.Batch(
 		m.table.Init(),
 		m.sparkline.Init(),
+		m.info.Init(),
+		m.clients.Init(),
 	)
 }
 
@@ -115,6 +176,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.chart == chart && m.chart == m.spectrum {
 			m.spectrum.NextBandView()
 		}
+		if m.chart == chart && m.chart == m.sparkline {
+			m.sparkline.NextField()
+		}
 		m.chart = chart
 		chartFocused(true)
 
@@ -136,6 +200,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	// keys typed in table filter are not hotkeys
+	filtering := m.table.Filtering()
+
 	{
 		model, cmd := m.table.Update(msg)
 		if m.table, ok = model.(*wifitable.Model); !ok {
@@ -160,10 +227,34 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 	}
 
+	{
+		model, cmd := m.info.Update(msg)
+		if m.info, ok = model.(*info.Model); !ok {
+			log.Fatalf("info update method returned unexpected model %v", model)
+		}
+		cmds = append(cmds, cmd)
+	}
+
+	{
+		model, cmd := m.clients.Update(msg)
+		if m.clients, ok = model.(*clients.Model); !ok {
+			log.Fatalf("clients update method returned unexpected model %v", model)
+		}
+		cmds = append(cmds, cmd)
+	}
+
 	switch msg := msg.(type) {
 	case events.TableWidthMsg:
 		m.width = int(msg)
+	case exportedMsg:
+		m.onExported(msg)
 	case tea.KeyMsg:
+		if filtering {
+			break
+		}
+		// status of the last action is shown until next key
+		m.status = ""
+
 		switch {
 		case key.Matches(msg, m.keys.Sparkline):
 			focusChart(m.sparkline)
@@ -182,6 +273,27 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// chartFocused(true)
 			// cmds = append(cmds, onChartRefresh())
 
+		case key.Matches(msg, m.keys.Info):
+			focusChart(m.info)
+
+		case key.Matches(msg, m.keys.Clients):
+			focusChart(m.clients)
+
+		case key.Matches(msg, m.keys.Export):
+			cmds = append(cmds, m.export())
+
+		case key.Matches(msg, m.keys.Pause):
+			m.replay.TogglePause()
+
+		case key.Matches(msg, m.keys.Step):
+			m.replay.Step()
+
+		case key.Matches(msg, m.keys.Faster):
+			m.replay.Faster()
+
+		case key.Matches(msg, m.keys.Slower):
+			m.replay.Slower()
+
 		case key.Matches(msg, m.keys.Help):
 			m.helpShown = !m.helpShown
 
@@ -194,6 +306,29 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// Exports networks in background.
+func (m *Model) export() tea.Cmd {
+	exporter := m.exporter
+	m.status = "exporting"
+
+	return func() tea.Msg {
+		path, err := exporter.Export()
+		return exportedMsg{path: path, err: err}
+	}
+}
+
+// Shows export result in chart title.
+func (m *Model) onExported(msg exportedMsg) {
+	if msg.err != nil {
+		log.Error(msg.err)
+		m.status = "export failed"
+		return
+	}
+
+	log.Infof("exported networks to %s", msg.path)
+	m.status = "exported " + msg.path
+}
+
 func (m *Model) View() string {
 	if m.helpShown {
 		return m.help.View(&m.keys)
@@ -207,6 +342,12 @@ func (m *Model) viewChartTitle() string {
 	if t, ok := m.chart.(widgets.WithTitle); ok {
 		title = t.Title()
 	}
+	if m.replay != nil {
+		title += " / replay " + m.replay.String()
+	}
+	if len(m.status) > 0 {
+		title += " / " + m.status
+	}
 	title = titleStyle.Render(title)
 	gaps := strings.Repeat("─", cmp.Max(0, (m.width-lipgloss.Width(title)))/2)
 	return lipgloss.JoinHorizontal(lipgloss.Center, gaps, title, gaps)
@@ -10,6 +10,13 @@ type KeyMap struct {
 	TableKeyMap wifitable.KeyMap
 	Spectrum    key.Binding
 	Sparkline   key.Binding
+	Info        key.Binding
+	Clients     key.Binding
+	Export      key.Binding
+	Pause       key.Binding
+	Step        key.Binding
+	Faster      key.Binding
+	Slower      key.Binding
 	Help        key.Binding
 	Quit        key.Binding
 }
@@ -23,7 +30,35 @@ func NewKeyMap() KeyMap {
 		),
 		Sparkline: key.NewBinding(
 			key.WithKeys("l"),
-			key.WithHelp("l", "signal sparkline"),
+			key.WithHelp("l", "signal/utilization sparkline"),
+		),
+		Info: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "network info"),
+		),
+		Clients: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "client stations"),
+		),
+		Export: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "export networks to file"),
+		),
+		Pause: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "pause/resume replay"),
+		),
+		Step: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next packet (paused)"),
+		),
+		Faster: key.NewBinding(
+			key.WithKeys("+", "="),
+			key.WithHelp("+", "faster replay"),
+		),
+		Slower: key.NewBinding(
+			key.WithKeys("-", "_"),
+			key.WithHelp("-", "slower replay"),
 		),
 		Help: key.NewBinding(
 			key.WithKeys("h", "?"),
@@ -39,11 +74,15 @@ func NewKeyMap() KeyMap {
 func (k *KeyMap) ShortHelp() []key.Binding {
 	return []key.Binding{
 		k.TableKeyMap.Sort,
+		k.TableKeyMap.SortNext,
 		k.TableKeyMap.Reset,
 		k.TableKeyMap.StationView,
 		k.TableKeyMap.SignalView,
+		k.TableKeyMap.Filter,
 		k.Spectrum,
 		k.Sparkline,
+		k.Info,
+		k.Clients,
 		k.Help,
 		k.Quit,
 	}
@@ -53,7 +92,22 @@ func (k *KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		k.TableKeyMap.MoveBindings(),
 		k.TableKeyMap.ViewBindings(),
-		{k.Spectrum, k.Sparkline},
-		{k.Help, k.Quit},
+		k.TableKeyMap.FilterBindings(),
+		{k.Spectrum, k.Sparkline, k.Info, k.Clients},
+		k.ReplayBindings(),
+		{k.Export, k.Help, k.Quit},
 	}
 }
+
+// Returns pcap replay bindings.
+func (k *KeyMap) ReplayBindings() []key.Binding {
+	return []key.Binding{k.Pause, k.Step, k.Faster, k.Slower}
+}
+
+// Enables or disables pcap replay bindings.
+func (k *KeyMap) SetReplayEnabled(enabled bool) {
+	k.Pause.SetEnabled(enabled)
+	k.Step.SetEnabled(enabled)
+	k.Faster.SetEnabled(enabled)
+	k.Slower.SetEnabled(enabled)
+}
@@ -0,0 +1,107 @@
+package info
+
+import (
+	"time"
+	netdata "wfmon/pkg/data/net"
+	"wfmon/pkg/ds"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	defaultWidth           = 95
+	defaultRefreshInterval = time.Second
+	sectionGap             = 2
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().Bold(true)
+	keyStyle   = lipgloss.NewStyle().Faint(true)
+)
+
+// Detail panel of highlighted network.
+type Model struct {
+	width   int
+	focused bool
+	content string
+
+	netKey     netdata.Key
+	network    netdata.Network
+	found      bool
+	dataSource ds.Provider
+}
+
+type Option func(*Model)
+
+func WithDataSource(dataSource ds.Provider) Option {
+	return func(m *Model) {
+		m.SetDataSource(dataSource)
+	}
+}
+
+func WithNetwork(key netdata.Key) Option {
+	return func(m *Model) {
+		m.SetNetworkKey(key)
+	}
+}
+
+func WithFocused(focus bool) Option {
+	return func(m *Model) {
+		m.Focused(focus)
+	}
+}
+
+func New(opts ...Option) *Model {
+	m := &Model{
+		width:      defaultWidth,
+		focused:    true,
+		dataSource: ds.EmptyProvider{},
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+func (m *Model) SetDataSource(dataSource ds.Provider) {
+	m.dataSource = dataSource
+}
+
+func (m *Model) SetNetworkKey(key netdata.Key) {
+	m.netKey = key
+}
+
+func (m *Model) NetworkKey() netdata.Key {
+	return m.netKey
+}
+
+func (m *Model) SetWidth(w int) {
+	m.width = w
+}
+
+func (m *Model) Width() int {
+	return m.width
+}
+
+func (m *Model) Focused(focus bool) {
+	m.focused = focus
+}
+
+func (m *Model) GetFocused() bool {
+	return m.focused
+}
+
+func (m *Model) Title() string {
+	if !m.found {
+		return "Info"
+	}
+
+	return "Info " + m.network.BSSID
+}
+
+// Views sections rendered by @refresh.
+func (m *Model) View() string {
+	return m.content
+}
@@ -0,0 +1,75 @@
+package info
+
+import (
+	"strings"
+	"time"
+	netdata "wfmon/pkg/data/net"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type refreshMsg time.Time
+
+// Invokes refresh panel by interval.
+// Fresh data obtained on timer end.
+func refreshTick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return refreshMsg(t)
+	})
+}
+
+// Returns network by current key from data source.
+func (m *Model) getData() (netdata.Network, bool) {
+	networks := m.dataSource.Networks()
+	for i := range networks {
+		if networks[i].Key().Compare(m.netKey) == 0 {
+			return networks[i], true
+		}
+	}
+
+	return netdata.Network{}, false
+}
+
+// Immediately renders sections of current network.
+// Sections are laid out in rows fitting the width.
+func (m *Model) refresh() {
+	if !m.focused {
+		return
+	}
+
+	if !m.found {
+		m.content = keyStyle.Render("no network selected")
+		return
+	}
+
+	rows := []string{}
+	row := []string{}
+	rowWidth := 0
+	for _, s := range sections(&m.network, m.dataSource.Stats(m.netKey)) {
+		block := s.render()
+		w := lipgloss.Width(block) + sectionGap
+
+		if rowWidth+w > m.width && len(row) > 0 {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row, rowWidth = []string{}, 0
+		}
+
+		row = append(row, lipgloss.NewStyle().PaddingRight(sectionGap).Render(block))
+		rowWidth += w
+	}
+	if len(row) > 0 {
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+
+	m.content = strings.Join(rows, "\n\n")
+}
+
+// Handles refresh tick.
+// Fetches network from data source.
+// Renders sections.
+func (m *Model) onRefreshMsg(_ refreshMsg) {
+	m.network, m.found = m.getData()
+
+	m.refresh()
+}
@@ -0,0 +1,356 @@
+package info
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	netdata "wfmon/pkg/data/net"
+	"wfmon/pkg/ts"
+	"wfmon/pkg/utils/cmp"
+	"wfmon/pkg/wifi"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const timeLayout = "15:04:05"
+
+// Titled list of key-value lines.
+type section struct {
+	title string
+	lines [][2]string
+}
+
+func (s *section) add(key, val string) *section {
+	s.lines = append(s.lines, [2]string{key, val})
+	return s
+}
+
+func (s *section) render() string {
+	keyWidth := 0
+	for _, line := range s.lines {
+		keyWidth = max(keyWidth, lipgloss.Width(line[0]))
+	}
+
+	rows := []string{titleStyle.Render(s.title)}
+	for _, line := range s.lines {
+		key := line[0] + strings.Repeat(" ", keyWidth-lipgloss.Width(line[0]))
+		rows = append(rows, keyStyle.Render(key)+" "+line[1])
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+// Returns sections of everything known about network.
+// Sections of information elements are omitted if elements were not advertised.
+func sections(n *netdata.Network, stats func(colKey string) ts.Stats) []section {
+	list := []section{
+		bssSection(n),
+		channelSection(n),
+		signalSection(n, stats),
+		securitySection(n),
+	}
+
+	for _, s := range []section{ratesSection(n), bssLoadSection(n), regulatorySection(n), wpsSection(n), htSection(n), vhtSection(n), heSection(n), ehtSection(n)} {
+		if len(s.lines) > 0 {
+			list = append(list, s)
+		}
+	}
+
+	return list
+}
+
+func bssSection(n *netdata.Network) section {
+	s := section{title: "BSS"}
+	s.add("SSID", ssidText(n)).
+		add("SSID encoding", cmp.Nvl(n.IE.UTF8SSID, "UTF-8", "unspecified")).
+		add("BSSID", n.BSSID).
+		add("Vendor", n.ManufLong).
+		add("PHY", n.PHY.String()).
+		add("Capabilities", n.CapabilityInfo.String()).
+		add("First seen", formatTime(n.FirstSeen)).
+		add("Last seen", formatTime(n.Timestamp)).
+		add("Frames", strconv.FormatUint(uint64(n.Frames), 10)).
+		add("Clients", strconv.Itoa(n.Clients))
+
+	// SSID changes including hidden to revealed
+	if len(n.SSIDs) > 1 {
+		for i, change := range n.SSIDs {
+			s.add("SSID "+strconv.Itoa(i+1), fmt.Sprintf("%s since %s",
+				cmp.Nvl(len(change.SSID) > 0, change.SSID, "<hidden>"), formatTime(change.Timestamp)))
+		}
+	}
+
+	return s
+}
+
+// Returns network name annotated if it is hidden or revealed.
+func ssidText(n *netdata.Network) string {
+	switch {
+	case n.Revealed():
+		return n.NetworkName + " (revealed)"
+	case n.Hidden:
+		return "<hidden>"
+	default:
+		return n.NetworkName
+	}
+}
+
+func channelSection(n *netdata.Network) section {
+	s := section{title: "Channel"}
+	s.add("Channel", strconv.Itoa(int(n.Channel))+cmp.Nvl(n.ChannelFromFrequency(), " (by frequency)", "")).
+		add("Frequency", formatFrequency(n.Frequency)).
+		add("Tuned channel", strconv.Itoa(int(wifi.FrequencyToChannel(n.Frequency)))).
+		add("Off-channel frames", strconv.FormatUint(uint64(n.OffChannelFrames), 10)).
+		add("Band", fmt.Sprintf("%s (%sGHz)", n.Band, n.Band.Range())).
+		add("Width", fmt.Sprintf("%dMHz", n.ChannelWidth)).
+		add("Width operation", n.WidthOperation.String()).
+		add("Secondary offset", n.Offset.String()).
+		add("Center segment 0", strconv.Itoa(int(n.FrequencyCenter0))).
+		add("Center segment 1", strconv.Itoa(int(n.FrequencyCenter1)))
+
+	return s
+}
+
+// Signal statistics cover all samples since network was discovered, the same as exported ones.
+func signalSection(n *netdata.Network, stats func(colKey string) ts.Stats) section {
+	format := func(key string, cur int8) string {
+		st := stats(key)
+		if st.Count == 0 {
+			return fmt.Sprintf("%d", cur)
+		}
+		return fmt.Sprintf("%d  %.f/%.1f/%.f", cur, st.Min, st.Avg, st.Max)
+	}
+
+	s := section{title: "Signal  now  min/avg/max"}
+	s.add("RSSI", format(netdata.RSSIKey, n.RSSI)+" dBm").
+		add("Noise", format(netdata.NoiseKey, n.Noise)+" dBm").
+		add("SNR", format(netdata.SNRKey, n.SNR)+" dB").
+		add("Quality", fmt.Sprintf("%d%%", n.Quality))
+
+	return s
+}
+
+func securitySection(n *netdata.Network) section {
+	s := section{title: "Security"}
+	s.add("Summary", n.Security.String())
+
+	for _, ie := range []struct {
+		name string
+		rsn  *wifi.RSNIE
+	}{
+		{"RSN", &n.SecurityIE.RSN},
+		{"WPA", &n.SecurityIE.WPA},
+	} {
+		if !ie.rsn.Present() {
+			continue
+		}
+
+		s.add(ie.name+" version", strconv.Itoa(int(ie.rsn.Version))).
+			add(ie.name+" group", ie.rsn.GroupCipher.String()).
+			add(ie.name+" pairwise", joinStrings(ie.rsn.PairwiseCiphers)).
+			add(ie.name+" AKM", joinStrings(ie.rsn.AKMSuites))
+
+		if ie.name == "RSN" {
+			s.add("MFP", formatMFP(ie.rsn))
+		}
+	}
+
+	return s
+}
+
+func ratesSection(n *netdata.Network) section {
+	s := section{title: "Rates, Mbps (* basic)"}
+
+	const (
+		basicFlag = 0x80
+		unitKbps  = 500
+	)
+
+	rates := make([]string, 0, len(n.IE.Rates))
+	for _, r := range n.IE.Rates {
+		rate := strconv.FormatFloat(float64(r&^basicFlag)*unitKbps/1000, 'f', -1, 64) //nolint:gomnd // ignore
+		if r&basicFlag != 0 {
+			rate += "*"
+		}
+		rates = append(rates, rate)
+	}
+
+	// wrap rates by lines of 6 values
+	const perLine = 6
+	for i := 0; i < len(rates); i += perLine {
+		s.add("", strings.Join(rates[i:min(i+perLine, len(rates))], " "))
+	}
+
+	return s
+}
+
+func htSection(n *netdata.Network) section {
+	s := section{title: "HT (802.11n)"}
+	if n.IE.HTSupported {
+		s.add("Capabilities", fmt.Sprintf("%#04x", n.IE.HTInfo)).
+			add("Streams", strconv.Itoa(int(n.IE.HTStreams)))
+	}
+	if n.IE.PrimaryChannel > 0 {
+		s.add("Primary channel", strconv.Itoa(int(n.IE.PrimaryChannel))).
+			add("Secondary offset", wifi.SecondaryChannelOffset(n.IE.SecondaryChannelOffset).String()).
+			add("Any width", strconv.FormatBool(n.IE.SupportedChannelWidth == 1))
+	}
+
+	return s
+}
+
+func vhtSection(n *netdata.Network) section {
+	s := section{title: "VHT (802.11ac)"}
+	if n.IE.VHTSupported {
+		s.add("Capabilities", fmt.Sprintf("%#08x", n.IE.VHTInfo)).
+			add("Streams", strconv.Itoa(int(n.IE.VHTStreams))).
+			add("Width operation", wifi.GetChannelWidthOperation(n.IE.VHTOperationIE.ChannelWidth).String()).
+			add("Center segment 0", strconv.Itoa(int(n.IE.VHTOperationIE.ChannelCenterSegment0))).
+			add("Center segment 1", strconv.Itoa(int(n.IE.VHTOperationIE.ChannelCenterSegment1)))
+	}
+
+	return s
+}
+
+func bssLoadSection(n *netdata.Network) section {
+	s := section{title: "BSS Load"}
+	if !n.BSSLoad.BSSLoadValid {
+		return s
+	}
+
+	s.add("Stations", strconv.Itoa(int(n.BSSLoad.StationCount))).
+		add("Utilization", fmt.Sprintf("%d%% (%d/255)", n.BSSLoad.Utilization(), n.BSSLoad.ChannelUtilization)).
+		add("Admission capacity", fmt.Sprintf("%dus/s", 32*int(n.BSSLoad.AdmissionCapacity))) //nolint:gomnd // ignore
+
+	return s
+}
+
+// Lists WPS device attributes, which identify device running AP, e.g. printer.
+func wpsSection(n *netdata.Network) section {
+	s := section{title: "WPS"}
+	if n.IE.P2P {
+		s.add("Wi-Fi Direct", cmp.Nvl(n.IE.P2PGroupOwner, "group owner", "yes"))
+	}
+	if !n.WPS.WPSValid {
+		return s
+	}
+
+	s.add("State", n.WPS.WPSState.String()).
+		add("AP setup locked", strconv.FormatBool(n.WPS.APSetupLocked))
+	for _, line := range [][2]string{
+		{"Device name", n.WPS.DeviceName},
+		{"Manufacturer", n.WPS.Manufacturer},
+		{"Model", n.WPS.Model()},
+		{"Serial number", n.WPS.SerialNumber},
+	} {
+		if len(line[1]) > 0 {
+			s.add(line[0], line[1])
+		}
+	}
+	if n.WPS.ConfigMethods != 0 {
+		s.add("Config methods", n.WPS.ConfigMethods.String())
+	}
+
+	return s
+}
+
+func regulatorySection(n *netdata.Network) section {
+	s := section{title: "Regulatory"}
+	if n.Country.Present() {
+		s.add("Country", n.Country.String())
+		if len(n.Country.Triplets) > 0 {
+			s.add("Channels", n.Country.TripletsString())
+		}
+		if power, ok := n.Country.MaxPower(n.Band.Range(), n.Channel); ok {
+			s.add("Max power", fmt.Sprintf("%ddBm", power))
+		}
+	}
+
+	for _, issue := range n.RegIssues {
+		s.add("Issue", issue)
+	}
+
+	return s
+}
+
+func heSection(n *netdata.Network) section {
+	s := section{title: "HE (802.11ax)"}
+	if !n.IE.HESupported {
+		return s
+	}
+
+	s.add("Operation", fmt.Sprintf("%#06x", n.IE.HEOperation)).
+		add("BSS color", strconv.Itoa(int(n.IE.BSSColor)))
+
+	if n.IE.HE6GHzInfoValid {
+		s.add("6GHz primary", strconv.Itoa(int(n.IE.HE6GHzPrimaryChannel))).
+			add("6GHz width", []string{"20", "40", "80", "160"}[n.IE.HE6GHzChannelWidth&0x03]+"MHz").
+			add("6GHz center 0", strconv.Itoa(int(n.IE.HE6GHzChannelCenter0))).
+			add("6GHz center 1", strconv.Itoa(int(n.IE.HE6GHzChannelCenter1))).
+			add("Duplicate beacon", strconv.FormatBool(n.IE.HE6GHzDuplicateBeacon))
+	}
+
+	return s
+}
+
+func ehtSection(n *netdata.Network) section {
+	s := section{title: "EHT (802.11be)"}
+	if !n.IE.EHTSupported {
+		return s
+	}
+
+	s.add("Supported", "true")
+	if n.IE.EHTOperationInfoValid {
+		s.add("Width", formatEHTWidth(n.IE.EHTChannelWidth)).
+			add("Center segment 0", strconv.Itoa(int(n.IE.EHTChannelCenterSeg0))).
+			add("Center segment 1", strconv.Itoa(int(n.IE.EHTChannelCenterSeg1)))
+	}
+
+	return s
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	return t.Format(timeLayout)
+}
+
+func formatFrequency(freq int) string {
+	if freq == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%dMHz", freq)
+}
+
+func formatEHTWidth(w uint8) string {
+	widths := []string{"20", "40", "80", "160", "320"}
+	if int(w) >= len(widths) {
+		return "reserved"
+	}
+
+	return widths[w] + "MHz"
+}
+
+func formatMFP(ie *wifi.RSNIE) string {
+	switch {
+	case ie.MFPRequired:
+		return "required"
+	case ie.MFPCapable:
+		return "capable"
+	default:
+		return "no"
+	}
+}
+
+func joinStrings[T fmt.Stringer](items []T) string {
+	names := make([]string, len(items))
+	for i := range items {
+		names[i] = items[i].String()
+	}
+
+	return strings.Join(names, ", ")
+}
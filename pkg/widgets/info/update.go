@@ -0,0 +1,45 @@
+package info
+
+import (
+	"wfmon/pkg/widgets/events"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m *Model) Init() tea.Cmd {
+	return refreshTick(defaultRefreshInterval)
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var (
+		// cmd  tea.Cmd
+		cmds []tea.Cmd
+	)
+
+	switch msg := msg.(type) {
+	case events.SelectedNetworkKeyMsg:
+		m.SetNetworkKey(msg.Key)
+		m.onRefreshMsg(refreshMsg{})
+
+	case events.NetworkKeyMsg:
+		// highlighted row could be changed by sorting or filtering
+		if m.netKey.Compare(msg.Key) != 0 {
+			m.SetNetworkKey(msg.Key)
+			m.onRefreshMsg(refreshMsg{})
+		}
+
+	case events.TableWidthMsg:
+		m.SetWidth(int(msg))
+		m.refresh()
+
+	case refreshMsg:
+		// Apply refresh data to sections
+		m.onRefreshMsg(msg)
+
+		// schedule next refresh tick
+		cmds = append(cmds, refreshTick(defaultRefreshInterval))
+	}
+
+	// Bubble up the cmds
+	return m, tea.Batch(cmds...)
+}
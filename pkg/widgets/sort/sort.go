@@ -27,9 +27,9 @@ func Sorter[T constraints.Ordered](fncGet func(n netdata.Slice, i int) T) FncSor
 			less: func(i, j int) bool {
 				// first sort by table field
 				cmp := cmp.Compare(fncGet(n, i), fncGet(n, j))
-				// then sort by table key
+				// then sort by network name and key
 				if cmp == 0 {
-					cmp = n[i].Key().Compare(n[j].Key())
+					cmp = n[i].Compare(&n[j])
 				}
 
 				return cmp < 0
@@ -41,14 +41,14 @@ func (s defaultSorter) Len() int           { return s.len() }
 func (s defaultSorter) Swap(i, j int)      { s.swap(i, j) }
 func (s defaultSorter) Less(i, j int) bool { return s.less(i, j) }
 
-// Default sorter by network key (SSID, BSSID).
+// Default sorter by network name and key (SSID, BSSID, band).
 func ByKeySorter() FncSorter {
 	return func(n netdata.Slice) sort.Interface {
 		return &defaultSorter{
 			len:  func() int { return len(n) },
 			swap: func(i, j int) { n[i], n[j] = n[j], n[i] },
 			less: func(i, j int) bool {
-				return n[i].Key().Compare(n[j].Key()) < 0
+				return n[i].Compare(&n[j]) < 0
 			},
 		}
 	}
@@ -1,7 +1,9 @@
 package sort
 
 import (
+	"strconv"
 	netdata "wfmon/pkg/data/net"
+	"wfmon/pkg/utils/conv"
 )
 
 // Sort by BSSID asc.
@@ -39,6 +41,11 @@ func ByBandwidthSorter() FncSorter {
 	return Sorter(func(n netdata.Slice, i int) int { return int(n[i].Band) })
 }
 
+// Sort by PHY generations asc.
+func ByPHYSorter() FncSorter {
+	return Sorter(func(n netdata.Slice, i int) int { return int(n[i].PHY) })
+}
+
 // Sort by RSSI asc.
 func ByRSSISorter() FncSorter {
 	return Sorter(func(n netdata.Slice, i int) int { return int(n[i].RSSI) })
@@ -63,3 +70,70 @@ func ByNoiseSorter() FncSorter {
 func BySNRSorter() FncSorter {
 	return Sorter(func(n netdata.Slice, i int) int { return int(n[i].SNR) })
 }
+
+// Sort by number of associated clients asc.
+func ByClientsSorter() FncSorter {
+	return Sorter(func(n netdata.Slice, i int) int { return n[i].Clients })
+}
+
+// Sort by number of stations advertised in BSS Load asc, networks without BSS Load first.
+func ByStationsSorter() FncSorter {
+	return Sorter(func(n netdata.Slice, i int) int {
+		if !n[i].BSSLoad.BSSLoadValid {
+			return -1
+		}
+		return int(n[i].BSSLoad.StationCount)
+	})
+}
+
+// Sort by channel utilization advertised in BSS Load asc, networks without BSS Load first.
+func ByUtilSorter() FncSorter {
+	return Sorter(func(n netdata.Slice, i int) int {
+		if !n[i].BSSLoad.BSSLoadValid {
+			return -1
+		}
+		return int(n[i].BSSLoad.ChannelUtilization)
+	})
+}
+
+// Sort by advertised country asc, networks violating regulatory domain first.
+func ByCountrySorter() FncSorter {
+	return Sorter(func(n netdata.Slice, i int) string {
+		return strconv.Itoa(conv.BoolToInt(len(n[i].RegIssues) == 0)) + n[i].Country.Country
+	})
+}
+
+// Sort by WPS device name asc.
+func ByWPSNameSorter() FncSorter {
+	return Sorter(func(n netdata.Slice, i int) string { return n[i].WPS.DeviceName })
+}
+
+// Sort by WPS device model asc.
+func ByWPSModelSorter() FncSorter {
+	return Sorter(func(n netdata.Slice, i int) string { return n[i].WPS.Model() })
+}
+
+// Sort by hidden SSID asc: visible, revealed, then hidden networks.
+func ByHiddenSorter() FncSorter {
+	return Sorter(func(n netdata.Slice, i int) int {
+		return conv.BoolToInt(n[i].Hidden) + conv.BoolToInt(n[i].Hidden && !n[i].Revealed())
+	})
+}
+
+// Sort by time since last seen asc, recently seen first.
+func BySeenSorter() FncSorter {
+	return Sorter(func(n netdata.Slice, i int) int64 { return -n[i].Timestamp.UnixNano() })
+}
+
+// Sort by time since first seen asc, recently discovered first.
+func ByAgeSorter() FncSorter {
+	return Sorter(func(n netdata.Slice, i int) int64 { return -n[i].FirstSeen.UnixNano() })
+}
+
+// Sort by Security protocols asc, weak ciphers first.
+func BySecuritySorter() FncSorter {
+	return Sorter(func(n netdata.Slice, i int) int {
+		sec := n[i].Security
+		return int(sec.Protocols)<<1 | conv.BoolToInt(!sec.Weak)
+	})
+}
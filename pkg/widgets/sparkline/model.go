@@ -37,9 +37,12 @@ type Model struct {
 	color     lipgloss.Color
 	axesShown bool
 
-	fieldKey   string
-	netKey     netdata.Key
-	dataSource ds.TimeSeriesProvider
+	fieldKey    string
+	signalField events.SignalFieldMsg // field selected in wifi table
+	altField    events.SignalFieldMsg // field charted alternatively, e.g. channel utilization
+	altShown    bool
+	netKey      netdata.Key
+	dataSource  ds.TimeSeriesProvider
 }
 
 type Option func(*Model)
@@ -82,9 +85,13 @@ func WithMaxVal(val float64) Option {
 
 func WithSignalField(msg events.SignalFieldMsg) Option {
 	return func(m *Model) {
-		m.SetFieldKey(msg.Key)
-		m.SetMinVal(msg.MinVal)
-		m.SetMaxVal(msg.MaxVal)
+		m.SetSignalField(msg)
+	}
+}
+
+func WithAltField(msg events.SignalFieldMsg) Option {
+	return func(m *Model) {
+		m.altField = msg
 	}
 }
 
@@ -148,6 +155,37 @@ func (m *Model) FieldKey() string {
 	return m.fieldKey
 }
 
+// Sets signal field, it is charted unless alternative field is shown.
+func (m *Model) SetSignalField(msg events.SignalFieldMsg) {
+	m.signalField = msg
+	if !m.altShown {
+		m.setField(msg)
+	}
+}
+
+// Switches chart between signal field and alternative field.
+func (m *Model) NextField() {
+	if len(m.altField.Key) == 0 {
+		return
+	}
+
+	m.altShown = !m.altShown
+	if m.altShown {
+		m.setField(m.altField)
+	} else {
+		m.setField(m.signalField)
+	}
+
+	m.data = m.getData()
+	m.refresh()
+}
+
+func (m *Model) setField(msg events.SignalFieldMsg) {
+	m.SetFieldKey(msg.Key)
+	m.SetMinVal(msg.MinVal)
+	m.SetMaxVal(msg.MaxVal)
+}
+
 func (m *Model) SetDimension(w, h int) {
 	m.viewport.Width = w
 	m.viewport.Height = h
@@ -30,7 +30,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.refresh()
 
 	case events.SignalFieldMsg:
-		WithSignalField(msg)(m)
+		m.SetSignalField(msg)
 
 		m.data = m.getData()
 		m.refresh()
@@ -15,12 +15,13 @@ const (
 	wave20MhzWidth                     = 4  // number of channels in a wave of 20Mhz width
 	halfOfWave80MhzWidthWithoutCenter  = 6  // number of channels in a wave of 80Mhz width excluding center segment
 	halfOfWave160MhzWidthWithoutCenter = 14 // number of channels in a wave of 160Mhz width excluding center segment
+	halfOfWave320MhzWidthWithoutCenter = 30 // number of channels in a wave of 320Mhz width excluding center segment
 	halfOfWave20MhzWidth               = 2  // number of channels in half of a wave of 20Mhz width
 )
 
 type Wave struct {
 	Key            netdata.Key                // network key
-	Band           wifi.Band                  // ISM or UNII
+	Band           wifi.Band                  // ISM or UNII (5GHz and 6GHz)
 	Value          float64                    // RSSI or Quality
 	Channel        uint8                      // primary channel
 	Sign           int8                       // HT secondary channel location: +1 above / -1 below
@@ -48,6 +49,8 @@ func (wave *Wave) LowerChannel() uint8 {
 		return cmp.Min(wave.Channel, wave.Center[0]-halfOfWave80MhzWidthWithoutCenter)
 	case wifi.WidthOperation160:
 		return cmp.Min(wave.Channel, wave.Center[0]-halfOfWave160MhzWidthWithoutCenter)
+	case wifi.WidthOperation320:
+		return cmp.Min(wave.Channel, wave.Center[0]-halfOfWave320MhzWidthWithoutCenter)
 	default:
 		return cmp.Min(wave.Channel, uint8(int8(wave.Channel)+wave.Sign*wave20MhzWidth*int8(wave.Width-1)))
 	}
@@ -46,6 +46,34 @@ func (m *Model) viewAxeXUNII3() string {
 	return rows.String()
 }
 
+func (m *Model) viewAxeXUNII5() string {
+	rows := strings.Builder{}
+	rows.WriteString("─────┰───────┰───────┰───────┰───────┰───────┰───────┰───────┰───────┰───────┰───────┰───────┰──────┤\n")
+	rows.WriteString("     1       9      17      25      33      41      49      57      65      73      81      89")
+	return rows.String()
+}
+
+func (m *Model) viewAxeXUNII6() string {
+	rows := strings.Builder{}
+	rows.WriteString("──────────┰───────────┰───────────┰───────────┰───────────┰──────┤\n")
+	rows.WriteString("         97          101         105         109         113")
+	return rows.String()
+}
+
+func (m *Model) viewAxeXUNII7() string {
+	rows := strings.Builder{}
+	rows.WriteString("──────────┰───────┰───────┰───────┰───────┰───────┰───────┰───────┰───────┰──────┤\n")
+	rows.WriteString("         117     125     133     141     149     157     165     173     181")
+	return rows.String()
+}
+
+func (m *Model) viewAxeXUNII8() string {
+	rows := strings.Builder{}
+	rows.WriteString("──────────┰───────┰───────┰───────┰───────┰───────┰──────┤\n")
+	rows.WriteString("         189     197     205     213     221     229")
+	return rows.String()
+}
+
 func (m *Model) viewAxeX() string {
 	//nolint:exhaustive // ignore
 	switch m.band {
@@ -61,6 +89,14 @@ func (m *Model) viewAxeX() string {
 		return m.viewAxeXUNII2C()
 	case wifi.UNII3:
 		return m.viewAxeXUNII3()
+	case wifi.UNII5:
+		return m.viewAxeXUNII5()
+	case wifi.UNII6:
+		return m.viewAxeXUNII6()
+	case wifi.UNII7:
+		return m.viewAxeXUNII7()
+	case wifi.UNII8:
+		return m.viewAxeXUNII8()
 	default:
 		return ""
 	}
@@ -114,6 +150,16 @@ func (m *Model) refreshByBand() {
 			return image.Point{X: -88, Y: 0}, 1
 		case wifi.UNII3:
 			return image.Point{X: -137, Y: 0}, 1
+		case wifi.UNII5:
+			//nolint:gomnd // ignore
+			return image.Point{X: 4, Y: 0}, 1
+		case wifi.UNII6:
+			//nolint:gomnd // ignore
+			return image.Point{X: -281, Y: 0}, 3
+		case wifi.UNII7:
+			return image.Point{X: -107, Y: 0}, 1
+		case wifi.UNII8:
+			return image.Point{X: -179, Y: 0}, 1
 		default:
 			return image.Point{}, 0
 		}
@@ -265,20 +311,28 @@ func (wave *Wave) render(buf *buffer.Buffer, r image.Rectangle, b lipgloss.Borde
 		return []rune(s)[0]
 	}
 
-	buf.SetCell(r.Min.X, r.Max.Y, fncR0(b.TopLeft), wave.Color)
+	// wide 6GHz waves might not fit in a viewport
+	var setCell = func(x, y int, r rune) {
+		if x < 0 || x >= buf.Width() || y < 0 || y >= buf.Height() {
+			return
+		}
+		buf.SetCell(x, y, r, wave.Color)
+	}
+
+	setCell(r.Min.X, r.Max.Y, fncR0(b.TopLeft))
 	for x := r.Min.X + 1; x < r.Max.X; x++ {
-		buf.SetCell(x, r.Max.Y, fncR0(b.Top), wave.Color)
+		setCell(x, r.Max.Y, fncR0(b.Top))
 	}
-	buf.SetCell(r.Max.X, r.Max.Y, fncR0(b.TopRight), wave.Color)
+	setCell(r.Max.X, r.Max.Y, fncR0(b.TopRight))
 
 	for y := r.Min.Y; y < r.Max.Y; y++ {
-		buf.SetCell(r.Min.X, y, fncR0(b.Left), wave.Color)
+		setCell(r.Min.X, y, fncR0(b.Left))
 		if fill != 0 {
 			for x := r.Min.X + 1; x < r.Max.X; x++ {
-				buf.SetCell(x, y, fill, wave.Color)
+				setCell(x, y, fill)
 			}
 		}
-		buf.SetCell(r.Max.X, y, fncR0(b.Right), wave.Color)
+		setCell(r.Max.X, y, fncR0(b.Right))
 	}
 }
 
@@ -386,7 +440,7 @@ func (wave *Wave) renderSecondary(buf *buffer.Buffer, r image.Rectangle) {
 
 // Renders a frequency center for VHT 80, 160 and 80+80.
 func (wave *Wave) renderCenter(buf *buffer.Buffer, r image.Rectangle) {
-	for x := r.Min.X; x < r.Max.X; x++ {
+	for x := cmp.Max(0, r.Min.X); x < cmp.Min(buf.Width(), r.Max.X); x++ {
 		for y := r.Min.Y; y < r.Max.Y; y++ {
 			buf.SetCell(x, y, '╎', wave.Color)
 		}
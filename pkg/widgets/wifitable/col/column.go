@@ -18,17 +18,22 @@ type Column interface {
 
 // Converts columns definition with applied sorting direction in the title to ordered array of @table.Column.
 func Converter(columns []Column) func(sort Sort) []table.Column {
+	return MarkedConverter(columns, nil)
+}
+
+// Converts columns definition like @Converter, appends marks to titles of columns by keys.
+func MarkedConverter(columns []Column, marks map[string]string) func(sort Sort) []table.Column {
 	// columns can be copied in generator
 	return func(sort Sort) []table.Column {
 		cols := make([]table.Column, len(columns))
 		for i := range columns {
 			col := columns[i]
 			key := col.Key()
-			title := col.Key()
+			title := col.Key() + marks[key]
 			width := col.Width()
 
 			if sort.Key() == key {
-				title = fmt.Sprintf("%s %s", key, sort.Order())
+				title = fmt.Sprintf("%s %s", title, sort.Order())
 			}
 
 			cols[i] = table.NewColumn(
@@ -3,6 +3,8 @@ package wifitable
 import (
 	"strconv"
 	netdata "wfmon/pkg/data/net"
+	"wfmon/pkg/utils/cmp"
+	"wfmon/pkg/utils/conv"
 	"wfmon/pkg/widgets/sort"
 	column "wfmon/pkg/widgets/wifitable/col"
 	"wfmon/pkg/widgets/wifitable/row"
@@ -27,6 +29,17 @@ const (
 	BarsKey       = netdata.BarsKey
 	NoiseKey      = netdata.NoiseKey
 	SNRKey        = netdata.SNRKey
+	SecurityKey   = netdata.SecurityKey
+	PHYKey        = netdata.PHYKey
+	SeenKey       = netdata.SeenKey
+	AgeKey        = netdata.AgeKey
+	ClientsKey    = netdata.ClientsKey
+	StationsKey   = netdata.StationsKey
+	UtilKey       = netdata.UtilKey
+	CountryKey    = netdata.CountryKey
+	HiddenKey     = netdata.HiddenKey
+	WPSNameKey    = netdata.WPSNameKey
+	WPSModelKey   = netdata.WPSModelKey
 )
 
 // Returns predefined columns width.
@@ -46,6 +59,17 @@ func widths() map[string]int {
 		BarsKey:       7,
 		NoiseKey:      8,
 		SNRKey:        5,
+		SecurityKey:   18,
+		PHYKey:        12,
+		SeenKey:       6,
+		AgeKey:        6,
+		ClientsKey:    8,
+		StationsKey:   10,
+		UtilKey:       7,
+		CountryKey:    8,
+		HiddenKey:     10,
+		WPSNameKey:    24,
+		WPSModelKey:   24,
 	}
 }
 
@@ -109,19 +133,67 @@ func SNRColumn() column.Simple {
 	return newColumn(SNRKey, sort.BySNRSorter())
 }
 
+func PHYColumn() column.Simple {
+	return newColumn(PHYKey, sort.ByPHYSorter()).
+		WithStyle(lipgloss.NewStyle().
+			Align(lipgloss.Left))
+}
+
+func SecurityColumn() column.Simple {
+	return newColumn(SecurityKey, sort.BySecuritySorter()).
+		WithStyle(lipgloss.NewStyle().
+			Align(lipgloss.Left))
+}
+
+func SeenColumn() column.Simple {
+	return newColumn(SeenKey, sort.BySeenSorter())
+}
+
+func AgeColumn() column.Simple {
+	return newColumn(AgeKey, sort.ByAgeSorter())
+}
+
+func ClientsColumn() column.Simple {
+	return newColumn(ClientsKey, sort.ByClientsSorter())
+}
+
+func StationsColumn() column.Simple {
+	return newColumn(StationsKey, sort.ByStationsSorter())
+}
+
+func UtilColumn() column.Simple {
+	return newColumn(UtilKey, sort.ByUtilSorter())
+}
+
+func CountryColumn() column.Simple {
+	return newColumn(CountryKey, sort.ByCountrySorter())
+}
+
+func WPSNameColumn() column.Simple {
+	return newColumn(WPSNameKey, sort.ByWPSNameSorter())
+}
+
+func WPSModelColumn() column.Simple {
+	return newColumn(WPSModelKey, sort.ByWPSModelSorter())
+}
+
+func HiddenColumn() column.Simple {
+	return newColumn(HiddenKey, sort.ByHiddenSorter())
+}
+
 func SignalColumn() column.Multiple {
 	return column.NewMultiple(BarsColumn(), RSSIColumn(), QualityColumn())
 }
 
 func StationColumn() column.Multiple {
-	return column.NewMultiple(BSSIDColumn(), ManufColumn(), ManufactorColumn())
+	return column.NewMultiple(BSSIDColumn(), ManufColumn(), ManufactorColumn(), WPSNameColumn(), WPSModelColumn())
 }
 
 // Index of MultiColumns in @columns array.
 // Hash column is not registered in hot keys for sorting.
 const (
 	StationMColumnIdx = 2
-	SignalMColumnIdx  = 6
+	SignalMColumnIdx  = 8
 )
 
 // Returns an ordered array of columns to view in a table.
@@ -135,9 +207,18 @@ func columns() []column.Column {
 		ChannelColumn(),
 		WidthColumn(),
 		BandColumn(),
+		PHYColumn(),
+		SecurityColumn(),
 		SignalColumn(),
 		NoiseColumn(),
 		SNRColumn(),
+		ClientsColumn(),
+		StationsColumn(),
+		UtilColumn(),
+		CountryColumn(),
+		HiddenColumn(),
+		SeenColumn(),
+		AgeColumn(),
 	}
 }
 
@@ -157,6 +238,17 @@ func simpleColumns() map[string]column.Simple {
 		BarsKey:       BarsColumn(),
 		NoiseKey:      NoiseColumn(),
 		SNRKey:        SNRColumn(),
+		SecurityKey:   SecurityColumn(),
+		PHYKey:        PHYColumn(),
+		SeenKey:       SeenColumn(),
+		AgeKey:        AgeColumn(),
+		ClientsKey:    ClientsColumn(),
+		StationsKey:   StationsColumn(),
+		UtilKey:       UtilColumn(),
+		CountryKey:    CountryColumn(),
+		HiddenKey:     HiddenColumn(),
+		WPSNameKey:    WPSNameColumn(),
+		WPSModelKey:   WPSModelColumn(),
 	}
 }
 
@@ -171,10 +263,18 @@ func cellViewers() map[string]row.FncCellViewer {
 			// Setup border.left/border.right with ' ' does not work and has side effects.
 			// Padding/Margin does not work properly on this column or right after this one.
 			// ref. https://github.com/Evertras/bubble-table/issues/130
-			// Thus manually truncate string.
-			// style := associatedStyle(data)
-			// return table.NewStyledCell(reflow.StringWithTail(data.NetworkName, widths[ColumnSSIDKey]-1), style)
-			return table.NewStyledCell(row.NetworkName, row.GetRowStyle())
+			// Thus manually truncate string by display width, wide runes take two cells.
+			name := wifi.SSID(row.NetworkName).Truncate(widths()[SSIDKey] - 1)
+			style := row.GetRowStyle()
+			switch {
+			// name of hidden network is not known yet
+			case row.Hidden && !row.Revealed():
+				return table.NewStyledCell("<hidden>", style.Copy().Faint(true))
+			// name of hidden network is revealed by probe response or association request
+			case row.Revealed():
+				style = style.Copy().Italic(true)
+			}
+			return table.NewStyledCell(name, style)
 		},
 		BSSIDKey: func(row *row.Data) any {
 			style := lipgloss.NewStyle().AlignHorizontal(lipgloss.Left).Inherit(row.GetRowStyle())
@@ -188,8 +288,26 @@ func cellViewers() map[string]row.FncCellViewer {
 			style := lipgloss.NewStyle().AlignHorizontal(lipgloss.Left).Inherit(row.GetRowStyle())
 			return table.NewStyledCell(row.ManufLong, style)
 		},
+		// WPS device is described in probe responses only
+		WPSNameKey: func(row *row.Data) any {
+			style := lipgloss.NewStyle().AlignHorizontal(lipgloss.Left).Inherit(row.GetRowStyle())
+			return table.NewStyledCell(wifi.SSID(row.WPS.DeviceName).Truncate(widths()[WPSNameKey]-1), style)
+		},
+		WPSModelKey: func(row *row.Data) any {
+			style := lipgloss.NewStyle().AlignHorizontal(lipgloss.Left).Inherit(row.GetRowStyle())
+			return table.NewStyledCell(wifi.SSID(row.WPS.Model()).Truncate(widths()[WPSModelKey]-1), style)
+		},
 		ChanKey: func(row *row.Data) any {
-			return table.NewStyledCell(strconv.Itoa(int(row.Channel)), row.GetRowStyle())
+			style := row.GetRowStyle()
+			switch {
+			// flag frames leaked from adjacent channel
+			case row.OffChannel:
+				style = lipgloss.NewStyle().Foreground(lipgloss.Color("#ffb347")).Inherit(style) // orange
+			// channel is derived from frequency
+			case row.ChannelFromFrequency():
+				style = style.Copy().Faint(true)
+			}
+			return table.NewStyledCell(strconv.Itoa(int(row.Channel)), style)
 		},
 		WidthKey: func(row *row.Data) any {
 			var text string
@@ -220,5 +338,63 @@ func cellViewers() map[string]row.FncCellViewer {
 		SNRKey: func(row *row.Data) any {
 			return table.NewStyledCell(strconv.Itoa(int(row.SNR)), row.GetRowStyle())
 		},
+		PHYKey: func(row *row.Data) any {
+			style := lipgloss.NewStyle().AlignHorizontal(lipgloss.Left).Inherit(row.GetRowStyle())
+			// flag legacy 802.11b only networks
+			if row.PHY.LegacyOnly() {
+				style = style.Foreground(lipgloss.Color("#ffb347")) // orange
+			}
+			return table.NewStyledCell(row.PHY.String(), style)
+		},
+		SeenKey: func(row *row.Data) any {
+			return table.NewStyledCell(conv.FormatAge(row.GetNow().Sub(row.Timestamp)), row.GetRowStyle())
+		},
+		AgeKey: func(row *row.Data) any {
+			return table.NewStyledCell(conv.FormatAge(row.GetNow().Sub(row.FirstSeen)), row.GetRowStyle())
+		},
+		ClientsKey: func(row *row.Data) any {
+			return table.NewStyledCell(strconv.Itoa(row.Clients), row.GetRowStyle())
+		},
+		// BSS Load is advertised by QoS APs only
+		StationsKey: func(row *row.Data) any {
+			text := "-"
+			if row.BSSLoad.BSSLoadValid {
+				text = strconv.Itoa(int(row.BSSLoad.StationCount))
+			}
+			return table.NewStyledCell(text, row.GetRowStyle())
+		},
+		UtilKey: func(row *row.Data) any {
+			text := "-"
+			if row.BSSLoad.BSSLoadValid {
+				text = strconv.Itoa(int(row.BSSLoad.Utilization()))
+			}
+			return table.NewStyledCell(text, row.GetRowStyle())
+		},
+		CountryKey: func(row *row.Data) any {
+			style := row.GetRowStyle()
+			// flag networks violating regulatory domain
+			if len(row.RegIssues) > 0 {
+				style = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff6961")).Inherit(style) // red
+			}
+			return table.NewStyledCell(cmp.Nvl(row.Country.Present(), row.Country.Country, "-"), style)
+		},
+		HiddenKey: func(row *row.Data) any {
+			text := "-"
+			switch {
+			case row.Revealed():
+				text = "revealed"
+			case row.Hidden:
+				text = "yes"
+			}
+			return table.NewStyledCell(text, row.GetRowStyle())
+		},
+		SecurityKey: func(row *row.Data) any {
+			style := lipgloss.NewStyle().AlignHorizontal(lipgloss.Left).Inherit(row.GetRowStyle())
+			// flag open and WEP/TKIP networks
+			if row.Security.Insecure() {
+				style = style.Foreground(lipgloss.Color("#ff6961")) // red
+			}
+			return table.NewStyledCell(row.Security.String(), style)
+		},
 	}
 }
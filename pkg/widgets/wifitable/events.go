@@ -33,3 +33,12 @@ func BarsFieldMsg() events.SignalFieldMsg {
 		MaxVal: 100,
 	}
 }
+
+// Returns channel utilization field, it is charted alternatively to signal field.
+func UtilFieldMsg() events.SignalFieldMsg {
+	return events.SignalFieldMsg{
+		Key:    UtilKey,
+		MinVal: 0,
+		MaxVal: 100,
+	}
+}
@@ -0,0 +1,148 @@
+package wifitable
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	netdata "wfmon/pkg/data/net"
+	netfilter "wfmon/pkg/filter"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const filterPrompt = "/"
+
+var (
+	defaultFilterStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("229"))
+)
+
+// Filters networks by expression, e.g. band == 5 && rssi > -70, or by SSID or BSSID.
+// Query is an expression, @name of saved expression or case insensitive regular expression of SSID or BSSID.
+// Invalid regular expression is matched as substring.
+type filter struct {
+	input textinput.Model
+	named netfilter.Named
+	expr  *netfilter.Filter
+	re    *regexp.Regexp
+	err   error
+}
+
+func newFilter() filter {
+	input := textinput.New()
+	input.Prompt = filterPrompt
+	input.PromptStyle = defaultFilterStyle
+	input.TextStyle = defaultFilterStyle
+	input.Placeholder = "SSID, BSSID, expression or @name"
+
+	return filter{input: input, named: netfilter.Named{}}
+}
+
+// Sets query and compiles it.
+func (f *filter) SetQuery(query string) {
+	f.input.SetValue(query)
+	f.compile()
+}
+
+// Returns true if query input is focused and consumes keys.
+func (f *filter) Editing() bool {
+	return f.input.Focused()
+}
+
+// Returns true if filter is shown, it is edited or has a query.
+func (f *filter) Visible() bool {
+	return f.Editing() || len(f.input.Value()) > 0
+}
+
+// Focuses query input.
+func (f *filter) Focus() tea.Cmd {
+	return f.input.Focus()
+}
+
+// Stops editing, keeps query applied.
+func (f *filter) Blur() {
+	f.input.Blur()
+}
+
+// Passes message to query input and compiles updated query.
+func (f *filter) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	f.input, cmd = f.input.Update(msg)
+	f.compile()
+
+	return cmd
+}
+
+// Compiles query typed in input.
+func (f *filter) compile() {
+	f.expr, f.re, f.err = nil, nil, nil
+
+	query := f.input.Value()
+	if len(query) == 0 {
+		return
+	}
+
+	// expression or saved one
+	if expr, err := f.named.Compile(query); err == nil {
+		f.expr = expr
+		return
+	} else if strings.HasPrefix(query, netfilter.NamedPrefix) {
+		f.err = err
+		return
+	}
+
+	re, err := regexp.Compile("(?i)" + query)
+	if err != nil {
+		re = regexp.MustCompile("(?i)" + regexp.QuoteMeta(query))
+	}
+	f.re = re
+}
+
+// Clears query and stops editing.
+func (f *filter) Reset() {
+	f.input.Reset()
+	f.input.Blur()
+	f.expr, f.re, f.err = nil, nil, nil
+}
+
+func (f *filter) Match(network *netdata.Network) bool {
+	switch {
+	case f.err != nil:
+		return false
+	case f.expr != nil:
+		return f.expr.Match(network)
+	case f.re == nil:
+		return true
+	}
+
+	return f.re.MatchString(network.NetworkName) || f.re.MatchString(network.BSSID)
+}
+
+// Returns networks matched by query.
+func (f *filter) Apply(networks netdata.Slice) netdata.Slice {
+	if f.expr == nil && f.re == nil && f.err == nil {
+		return networks
+	}
+
+	filtered := make(netdata.Slice, 0, len(networks))
+	for i := range networks {
+		if f.Match(&networks[i]) {
+			filtered = append(filtered, networks[i])
+		}
+	}
+
+	return filtered
+}
+
+// Returns filter line with number of matched networks.
+func (f *filter) View(matched, total int) string {
+	switch {
+	case f.err != nil:
+		return f.input.View() + defaultFilterStyle.Render(" ("+f.err.Error()+")")
+	case f.expr == nil && f.re == nil:
+		return f.input.View()
+	}
+
+	return f.input.View() + defaultFilterStyle.Render(fmt.Sprintf(" (%d/%d)", matched, total))
+}
@@ -16,7 +16,12 @@ type KeyMap struct {
 	SignalView  key.Binding
 	StationView key.Binding
 	Sort        key.Binding
+	SortPrev    key.Binding
+	SortNext    key.Binding
 	Reset       key.Binding
+	Filter      key.Binding
+	FilterApply key.Binding
+	FilterClear key.Binding
 }
 
 func NewKeyMap() KeyMap {
@@ -61,20 +66,40 @@ func NewKeyMap() KeyMap {
 		),
 		StationView: key.NewBinding(
 			key.WithKeys("ctrl+@"),
-			key.WithHelp("ctrl+@", "swap BSSID/Vendor"),
+			key.WithHelp("ctrl+@", "swap BSSID/Vendor/WPS"),
 		),
 		SignalView: key.NewBinding(
 			key.WithKeys("ctrl+^"),
 			key.WithHelp("ctrl+^", "swap RSSI/Quality/Bars"),
 		),
 		Sort: key.NewBinding(
-			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8"),
-			key.WithHelp("[1:8]", "sort"),
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
+			key.WithHelp("[1:9]", "sort"),
+		),
+		SortPrev: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "sort by prev column"),
+		),
+		SortNext: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "sort by next column"),
 		),
 		Reset: key.NewBinding(
 			key.WithKeys("0"),
 			key.WithHelp("0", "reset view"),
 		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter by SSID/BSSID"),
+		),
+		FilterApply: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("⏎", "apply filter"),
+		),
+		FilterClear: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "clear filter"),
+		),
 	}
 }
 
@@ -83,5 +108,9 @@ func (k *KeyMap) MoveBindings() []key.Binding {
 }
 
 func (k *KeyMap) ViewBindings() []key.Binding {
-	return []key.Binding{k.Sort, k.Reset, k.StationView, k.SignalView, k.RowSelectToggle}
+	return []key.Binding{k.Sort, k.SortPrev, k.SortNext, k.Reset, k.StationView, k.SignalView, k.RowSelectToggle}
+}
+
+func (k *KeyMap) FilterBindings() []key.Binding {
+	return []key.Binding{k.Filter, k.FilterApply, k.FilterClear}
 }
@@ -4,6 +4,7 @@ import (
 	"time"
 	netdata "wfmon/pkg/data/net"
 	"wfmon/pkg/ds"
+	netfilter "wfmon/pkg/filter"
 	log "wfmon/pkg/logger"
 	"wfmon/pkg/widgets/color"
 	column "wfmon/pkg/widgets/wifitable/col"
@@ -17,7 +18,8 @@ import (
 const (
 	defaultRefreshInterval = time.Second
 	defaultTableHeight     = 10
-	defaultTableWidth      = 120
+	defaultTableWidth      = 171
+	defaultStaleInterval   = 2 * time.Minute
 )
 
 var (
@@ -31,7 +33,9 @@ type Model struct {
 	table.Model
 	viewport   viewport.Model
 	dataSource ds.NetworkProvider
-	networks   netdata.Slice
+	networks   netdata.Slice // filtered and sorted networks shown in the table
+	all        netdata.Slice // networks fetched from data source
+	filter     filter
 	colors     map[netdata.Key]color.HexColor
 	associated netdata.Key
 	// selected   netdata.Key
@@ -54,6 +58,20 @@ func WithAssociated(key netdata.Key) Option {
 	}
 }
 
+// Sets saved filters available by @name in filter input.
+func WithNamedFilters(named netfilter.Named) Option {
+	return func(m *Model) {
+		m.filter.named = named
+	}
+}
+
+// Sets initial filter query, e.g. expression given in command line.
+func WithFilter(query string) Option {
+	return func(m *Model) {
+		m.filter.SetQuery(query)
+	}
+}
+
 func WithFocused(focus bool) Option {
 	return func(m *Model) {
 		m.Focused(focus)
@@ -83,6 +101,8 @@ func New(opts ...Option) *Model {
 		columns:    cols,
 		sort:       sort,
 		networks:   netdata.Slice{},
+		all:        netdata.Slice{},
+		filter:     newFilter(),
 		colors:     map[netdata.Key]color.HexColor{},
 		dataSource: ds.EmptyProvider{},
 	}
@@ -91,6 +111,9 @@ func New(opts ...Option) *Model {
 		opt(m)
 	}
 
+	// named filters may be set after initial query
+	m.filter.compile()
+
 	return m
 }
 
@@ -103,12 +126,27 @@ func (m *Model) Keys() KeyMap {
 }
 
 func (m *Model) View() string {
+	views := []string{m.Model.View()}
+
+	// reserve a line for filter input
+	m.viewport.Height = defaultTableHeight + 2
+	if m.filter.Visible() {
+		views = append(views, m.filter.View(len(m.networks), len(m.all)))
+		m.viewport.Height++
+	}
+
 	m.viewport.SetContent(
-		lipgloss.JoinVertical(lipgloss.Left, m.Model.View()),
+		lipgloss.JoinVertical(lipgloss.Left, views...),
 	)
 	return m.viewport.View()
 }
 
+// Returns true if filter input is focused.
+// Keys are consumed by the input, other widgets should ignore them.
+func (m *Model) Filtering() bool {
+	return m.filter.Editing()
+}
+
 func (m *Model) SetWidth(w int) {
 	m.Model.WithMaxTotalWidth(w)
 	m.viewport.Width = w
@@ -2,6 +2,8 @@ package wifitable
 
 import (
 	"time"
+	netdata "wfmon/pkg/data/net"
+	"wfmon/pkg/ds"
 	"wfmon/pkg/widgets/color"
 	column "wfmon/pkg/widgets/wifitable/col"
 	"wfmon/pkg/widgets/wifitable/row"
@@ -25,7 +27,24 @@ func refreshTick(interval time.Duration) tea.Cmd {
 func (m *Model) refresh() {
 	m.Model = m.
 		WithRows(m.getRows()).
-		WithColumns(column.Converter(m.columns)(m.sort))
+		WithColumns(column.MarkedConverter(m.columns, m.titleMarks())(m.sort))
+}
+
+// Returns marks of smoothing mode for signal columns, if data source smooths values.
+func (m *Model) titleMarks() map[string]string {
+	provider, ok := m.dataSource.(ds.SmoothingProvider)
+	if !ok {
+		return nil
+	}
+
+	mark := provider.Smoothing().Mode.Mark()
+	return map[string]string{
+		RSSIKey:    mark,
+		QualityKey: mark,
+		BarsKey:    mark,
+		NoiseKey:   mark,
+		SNRKey:     mark,
+	}
 }
 
 // Returns table rows from networks.
@@ -33,18 +52,29 @@ func (m *Model) refresh() {
 func (m *Model) getRows() []table.Row {
 	viewer := row.Converter(m.columns, cellViewers())
 
+	var now time.Time
+	if clock, ok := m.dataSource.(ds.Clock); ok {
+		now = clock.Now()
+	}
+
 	rows := make([]table.Row, len(m.networks))
 	for rowID, e := range m.networks {
 		entry := e
 
 		rowStyle := defaultBaseStyle
-		if entry.Key().Compare(m.associated) == 0 {
+		if m.isAssociated(&entry) {
 			rowStyle = defaultAssociatedStyle
 		}
 
+		// dim networks not seen for a while
+		if !now.IsZero() && now.Sub(entry.Timestamp) > defaultStaleInterval {
+			rowStyle = rowStyle.Copy().Faint(true)
+		}
+
 		data := row.Data{Network: entry}.
 			HashColor(m.colors[entry.Key()].Lipgloss()).
-			Style(rowStyle)
+			Style(rowStyle).
+			Now(now)
 
 		rows[rowID] = viewer(&data)
 	}
@@ -52,25 +82,42 @@ func (m *Model) getRows() []table.Row {
 	return rows
 }
 
+// Returns true if network is the associated one, in any band unless band is given.
+func (m *Model) isAssociated(n *netdata.Network) bool {
+	if len(m.associated.BSSID) == 0 || n.BSSID != m.associated.BSSID {
+		return false
+	}
+
+	return len(m.associated.Band) == 0 || n.Band.Range() == m.associated.Band
+}
+
 // Handles refresh tick.
 // Fetches networks from data source.
-// Sorts networks as per current column and order.
-// Invokes @refresh to redraw the table.
+// Invokes @applyFilter to filter, sort and redraw the table.
 func (m *Model) onRefreshMsg(_ refreshMsg) {
-	selectedNetwork, _ := m.GetSelectedNetwork()
-
 	// FIXME: race at access to networks and colors in update.
-	m.networks = m.dataSource.Networks()
+	m.all = m.dataSource.Networks()
 
 	iter := color.Random()
 	// preserve row colors
-	for _, network := range m.networks {
+	for _, network := range m.all {
 		key := network.Key()
 		if _, found := m.colors[key]; !found {
 			m.colors[key], iter = iter()
 		}
 	}
 
+	m.applyFilter()
+}
+
+// Filters fetched networks by current query.
+// Sorts networks as per current column and order.
+// Invokes @refresh to redraw the table.
+func (m *Model) applyFilter() {
+	selectedNetwork, _ := m.GetSelectedNetwork()
+
+	m.networks = m.filter.Apply(m.all)
+
 	// apply current sorting
 	m.sort.Sort(m.networks)
 
@@ -1,6 +1,7 @@
 package row
 
 import (
+	"time"
 	netdata "wfmon/pkg/data/net"
 	column "wfmon/pkg/widgets/wifitable/col"
 
@@ -14,6 +15,7 @@ type propKey int
 const (
 	rowStyle  propKey = iota // style for each cell in a row (default, associated network, etc)
 	hashColor                // first column (#) with uniq color per network
+	now                      // current time to view age of network
 )
 
 type props map[propKey]any
@@ -75,6 +77,18 @@ func (r Data) GetHashColor() lipgloss.TerminalColor {
 	return r.getAsColor(hashColor)
 }
 
+func (r Data) Now(t time.Time) Data {
+	r.set(now, t)
+	return r
+}
+
+func (r Data) GetNow() time.Time {
+	if t, ok := r.opts[now].(time.Time); ok {
+		return t
+	}
+	return time.Time{}
+}
+
 func (r Data) Style(s lipgloss.Style) Data {
 	r.set(rowStyle, s)
 	return r
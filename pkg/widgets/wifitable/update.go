@@ -122,21 +122,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// Sorts table by column index.
 	// Numbering starts from SSID column.
-	var sortColumn = func(msg tea.KeyMsg) tea.Cmd {
-		var num, idx int
-		var err error
-		if num, err = strconv.Atoi(msg.String()); err != nil {
-			log.Warnf("failed to sort, %w", err)
-			return nil
-		}
-
-		// Column number starts from 1
-		// Hash column is not registered for sorting
-		idx = num
-
+	var sortColumnIdx = func(idx int) tea.Cmd {
 		keys := visibleColumnKeys(m.columns)
 		if idx < 0 || idx >= len(keys) {
-			log.Warnf("unsupported sort key, %d", num)
+			log.Warnf("unsupported sort key, %d", idx)
 			return nil
 		}
 
@@ -158,6 +147,69 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return tea.Batch(onPageUpdate(), onHighlightedCmd())
 	}
 
+	// Sorts table by column number from key message.
+	var sortColumn = func(msg tea.KeyMsg) tea.Cmd {
+		num, err := strconv.Atoi(msg.String())
+		if err != nil {
+			log.Warnf("failed to sort, %w", err)
+			return nil
+		}
+
+		// Column number starts from 1
+		// Hash column is not registered for sorting
+		return sortColumnIdx(num)
+	}
+
+	// Sorts table by column next to currently sorted one in given direction.
+	// Allows sorting by columns beyond numeric hot keys.
+	var shiftSortColumn = func(delta int) tea.Cmd {
+		keys := visibleColumnKeys(m.columns)
+
+		idx := 0
+		for i, key := range keys {
+			if key == m.sort.Key() {
+				idx = i
+			}
+		}
+
+		// cycle, hash column is not registered for sorting
+		if idx += delta; idx < 1 {
+			idx = len(keys) - 1
+		} else if idx >= len(keys) {
+			idx = 1
+		}
+
+		return sortColumnIdx(idx)
+	}
+
+	// Handles keys typed in filter input.
+	// Applies query to the table as you type.
+	var onFilterKey = func(msg tea.KeyMsg) tea.Cmd {
+		var cmd tea.Cmd
+
+		switch {
+		case key.Matches(msg, m.keys.FilterApply):
+			m.filter.Blur()
+		case key.Matches(msg, m.keys.FilterClear):
+			m.filter.Reset()
+		default:
+			cmd = m.filter.Update(msg)
+		}
+
+		m.applyFilter()
+
+		return tea.Batch(cmd, onPageUpdate(), onHighlightedCmd())
+	}
+
+	// filter input consumes all keys
+	if m.filter.Editing() {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			return m, onFilterKey(msg)
+		}
+		// cursor blink
+		cmds = append(cmds, m.filter.Update(msg))
+	}
+
 	m.Model, cmd = m.Model.Update(msg)
 	cmds = append(cmds, cmd)
 
@@ -212,9 +264,22 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// TODO: send onSelectedCmd?
 			cmds = append(cmds, sortColumn(msg))
 
+		case key.Matches(msg, m.keys.SortPrev):
+			cmds = append(cmds, shiftSortColumn(-1))
+
+		case key.Matches(msg, m.keys.SortNext):
+			cmds = append(cmds, shiftSortColumn(1))
+
 		case key.Matches(msg, m.keys.RowSelectToggle):
 			cmds = append(cmds, onToggleCmd())
 
+		case key.Matches(msg, m.keys.Filter):
+			cmds = append(cmds, m.filter.Focus())
+
+		case key.Matches(msg, m.keys.FilterClear):
+			m.filter.Reset()
+			m.applyFilter()
+			cmds = append(cmds, onPageUpdate(), onHighlightedCmd())
 		}
 
 	case refreshMsg:
@@ -1,5 +1,7 @@
 package wifi
 
+import "wfmon/pkg/wifi/chanfreq"
+
 // https://mrncciew.com/2014/10/15/cwap-2-4ghz-vs-5ghz/
 type Band uint8
 
@@ -11,11 +13,17 @@ const (
 	UNII2B
 	UNII2C
 	UNII3
+	UNII5 // 6 GHz (Wi-Fi 6E/7)
+	UNII6
+	UNII7
+	UNII8
+	PublicSafety // 4.9 GHz (public safety in US, 802.11j in Japan)
 )
 
+// Bands viewed in spectrum.
 const (
 	MinBand = ISM
-	MaxBand = UNII3
+	MaxBand = UNII8
 )
 
 func (b Band) String() string {
@@ -27,14 +35,32 @@ func (b Band) String() string {
 		UNII2B:  "U-NII-2B",
 		UNII2C:  "U-NII-2C",
 		UNII3:   "U-NII-3",
+		UNII5:   "U-NII-5",
+		UNII6:   "U-NII-6",
+		UNII7:   "U-NII-7",
+		UNII8:   "U-NII-8",
+
+		PublicSafety: "PS",
 	}[b]
 }
 
 func (b Band) Range() string {
-	return []string{Unknown: "", ISM: "2.4", UNII1: "5", UNII2A: "5", UNII2B: "5", UNII2C: "5", UNII3: "5"}[b]
+	return []string{
+		Unknown: "",
+		ISM:     "2.4",
+		UNII1:   "5", UNII2A: "5", UNII2B: "5", UNII2C: "5", UNII3: "5",
+		UNII5: "6", UNII6: "6", UNII7: "6", UNII8: "6",
+		PublicSafety: "4.9",
+	}[b]
+}
+
+// Returns true for 6GHz bands.
+func (b Band) Is6GHz() bool {
+	return b >= UNII5 && b <= UNII8
 }
 
 // Returns '2.4GHz' or '5GHz'.
+// Channel numbers of 6GHz overlap with 2.4GHz and 5GHz, use @GetBandByFreq when frequency is known.
 // TODO: review and actualize bounds.
 func GetBandByChan(channel uint8) Band {
 	switch {
@@ -52,3 +78,75 @@ func GetBandByChan(channel uint8) Band {
 		return Unknown
 	}
 }
+
+// Returns band by channel center frequency in MHz.
+// https://en.wikipedia.org/wiki/List_of_WLAN_channels
+//
+//nolint:gomnd // ignore
+func GetBandByFreq(freq int) Band {
+	switch {
+	case freq >= 2401 && freq <= 2495:
+		return ISM
+	case freq >= chanfreq.MinPublicSafetyMHz && freq <= chanfreq.MaxPublicSafetyMHz:
+		return PublicSafety
+	case freq >= 5150 && freq < 5250:
+		return UNII1
+	case freq >= 5250 && freq < 5350:
+		return UNII2A
+	case freq >= 5350 && freq < 5470:
+		return UNII2B
+	case freq >= 5470 && freq < 5725:
+		return UNII2C
+	case freq >= 5725 && freq < 5925:
+		return UNII3
+	case freq >= 5925 && freq < 6425:
+		return UNII5
+	case freq >= 6425 && freq < 6525:
+		return UNII6
+	case freq >= 6525 && freq < 6875:
+		return UNII7
+	case freq >= 6875 && freq <= 7125:
+		return UNII8
+	default:
+		return Unknown
+	}
+}
+
+// Returns 6GHz band by channel number.
+//
+//nolint:gomnd // ignore
+func GetBandBy6GHzChan(channel uint8) Band {
+	switch {
+	case channel >= 1 && channel <= 93:
+		return UNII5
+	case channel >= 97 && channel <= 113:
+		return UNII6
+	case channel >= 117 && channel <= 185:
+		return UNII7
+	case channel >= 189 && channel <= 233:
+		return UNII8
+	default:
+		return Unknown
+	}
+}
+
+// Returns band by observed frequency and advertised channel.
+// Frequency determines band range only, as it might be tuned to an adjacent or any of bonded channels,
+// 5GHz and 6GHz sub-bands follow the channel.
+// Falls back to channel number when frequency is unknown.
+func GetBand(freq int, channel uint8) Band {
+	band := GetBandByFreq(freq)
+	if band == Unknown {
+		return GetBandByChan(channel)
+	}
+
+	sub := GetBandByChan(channel)
+	if band.Is6GHz() {
+		sub = GetBandBy6GHzChan(channel)
+	}
+	if sub != Unknown && sub.Range() == band.Range() {
+		return sub
+	}
+
+	return band
+}
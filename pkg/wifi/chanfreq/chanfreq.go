@@ -0,0 +1,101 @@
+// Package chanfreq maps Wi-Fi channel numbers to channel center frequencies and back.
+// It has no dependencies, so frame decoding and radio control share the same mapping.
+// https://en.wikipedia.org/wiki/List_of_WLAN_channels
+package chanfreq
+
+// Band range with its own channel numbering.
+type Range uint8
+
+const (
+	RangeUnknown Range = iota
+	Range2GHz          // 2.4GHz
+	Range4GHz          // 4.9GHz public safety
+	Range5GHz          // 5GHz
+	Range6GHz          // 6GHz
+)
+
+// Channel numbering of bands: channel center frequency is start + 5MHz * channel number.
+const (
+	channelSpacing    = 5    // MHz between adjacent channel numbers
+	ismStart          = 2407 // 2.4GHz, channels 1-13
+	ismChannel14      = 2484 // 2.4GHz channel 14 is off the grid, Japan 802.11b only
+	publicSafetyStart = 4000 // 4.9GHz, channels 182-198
+	uniiStart         = 5000 // 5GHz, channels 32-177
+	unii6GHzStart     = 5950 // 6GHz, channels 1-233
+	unii6GHzChannel2  = 5935 // 6GHz channel 2 is off the grid
+)
+
+// Band range bounds of channel center frequencies, MHz.
+const (
+	MinPublicSafetyMHz = 4910
+	MaxPublicSafetyMHz = 4990
+	Min5GHzMHz         = 5150
+	Min6GHzMHz         = 5925
+	Max6GHzMHz         = 7125
+)
+
+// Returns channel number by channel center frequency in MHz, 0 if frequency is out of known bands.
+//
+//nolint:gomnd // ignore
+func ToChannel(freq int) uint8 {
+	var ch int
+	switch {
+	case freq == ismChannel14:
+		return 14
+	case freq > ismStart && freq < ismChannel14:
+		ch = (freq - ismStart) / channelSpacing
+	case freq >= MinPublicSafetyMHz && freq <= MaxPublicSafetyMHz:
+		ch = (freq - publicSafetyStart) / channelSpacing
+	case freq >= Min5GHzMHz && freq < Min6GHzMHz:
+		ch = (freq - uniiStart) / channelSpacing
+	case freq == unii6GHzChannel2:
+		return 2
+	case freq > unii6GHzStart && freq <= Max6GHzMHz:
+		ch = (freq - unii6GHzStart) / channelSpacing
+	default:
+		return 0
+	}
+
+	return uint8(ch)
+}
+
+// Returns channel center frequency in MHz by channel number and band range, 0 if range is unknown.
+// Channel numbers of 2.4GHz, 5GHz and 6GHz bands overlap, thus range is required.
+func ToFrequency(channel uint8, r Range) int {
+	ch := int(channel)
+
+	switch {
+	case channel == 0:
+		return 0
+	case r == Range2GHz && channel == 14: //nolint:gomnd // ignore
+		return ismChannel14
+	case r == Range2GHz:
+		return ismStart + ch*channelSpacing
+	case r == Range4GHz:
+		return publicSafetyStart + ch*channelSpacing
+	case r == Range6GHz && channel == 2: //nolint:gomnd // ignore
+		return unii6GHzChannel2
+	case r == Range6GHz:
+		return unii6GHzStart + ch*channelSpacing
+	case r == Range5GHz:
+		return uniiStart + ch*channelSpacing
+	default:
+		return 0
+	}
+}
+
+// Returns band range of 2.4GHz or 5GHz channel number, unknown for others.
+// Legacy channel numbers are assumed when band is not known, e.g. channels given by user,
+// overlapping 6GHz and 4.9GHz channels are never assumed.
+//
+//nolint:gomnd // ignore
+func LegacyRange(channel uint8) Range {
+	switch {
+	case channel >= 1 && channel <= 14:
+		return Range2GHz
+	case channel >= 32 && channel <= 177:
+		return Range5GHz
+	default:
+		return RangeUnknown
+	}
+}
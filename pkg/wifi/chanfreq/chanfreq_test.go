@@ -0,0 +1,70 @@
+package chanfreq
+
+import "testing"
+
+func TestMapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		freq    int
+		channel uint8
+		r       Range
+	}{
+		{"2.4GHz first", 2412, 1, Range2GHz},
+		{"2.4GHz last on grid", 2472, 13, Range2GHz},
+		{"2.4GHz channel 14", 2484, 14, Range2GHz},
+		{"4.9GHz", 4940, 188, Range4GHz},
+		{"5GHz U-NII-1", 5180, 36, Range5GHz},
+		{"5GHz U-NII-3", 5825, 165, Range5GHz},
+		{"6GHz channel 1", 5955, 1, Range6GHz},
+		{"6GHz channel 2", 5935, 2, Range6GHz},
+		{"6GHz last", 7115, 233, Range6GHz},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToChannel(tt.freq); got != tt.channel {
+				t.Errorf("ToChannel(%d) = %d, want %d", tt.freq, got, tt.channel)
+			}
+			if got := ToFrequency(tt.channel, tt.r); got != tt.freq {
+				t.Errorf("ToFrequency(%d) = %d, want %d", tt.channel, got, tt.freq)
+			}
+		})
+	}
+}
+
+func TestUnknown(t *testing.T) {
+	for _, freq := range []int{0, 2400, 2407, 4900, 5000, 7200} {
+		if got := ToChannel(freq); got != 0 {
+			t.Errorf("ToChannel(%d) = %d, want 0", freq, got)
+		}
+	}
+
+	if got := ToFrequency(0, Range2GHz); got != 0 {
+		t.Errorf("ToFrequency(0) = %d, want 0", got)
+	}
+	if got := ToFrequency(36, RangeUnknown); got != 0 {
+		t.Errorf("ToFrequency of unknown range = %d, want 0", got)
+	}
+}
+
+func TestLegacyRange(t *testing.T) {
+	tests := []struct {
+		channel uint8
+		want    Range
+	}{
+		{0, RangeUnknown},
+		{1, Range2GHz},
+		{14, Range2GHz},
+		{15, RangeUnknown},
+		{36, Range5GHz},
+		{177, Range5GHz},
+		{184, RangeUnknown}, // 4.9GHz
+		{233, RangeUnknown}, // 6GHz only
+	}
+
+	for _, tt := range tests {
+		if got := LegacyRange(tt.channel); got != tt.want {
+			t.Errorf("LegacyRange(%d) = %d, want %d", tt.channel, got, tt.want)
+		}
+	}
+}
@@ -2,7 +2,7 @@ package wifi
 
 // Returns width in MHz.
 func GetChannelWidth(frame Frame) uint16 {
-	band := GetBandByChan(frame.Channel)
+	band := GetBand(frame.Frequency, frame.Channel)
 	// Unknown bandwidth
 	if band == Unknown {
 		return 0
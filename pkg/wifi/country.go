@@ -0,0 +1,86 @@
+package wifi
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Returns true if Country element was advertised.
+func (ie *CountryIE) Present() bool {
+	return len(ie.Country) > 0
+}
+
+// Returns true if subband triplets cover any channel of 2.4GHz or 5GHz band given as range, e.g. "5".
+// 6GHz channels are advertised by operating triplets only.
+func (ie *CountryIE) Covers(bandRange string) bool {
+	for _, t := range ie.Triplets {
+		if t.Band().Range() == bandRange {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns maximum transmit power on channel of 2.4GHz or 5GHz band, dBm.
+// Returns false if channel is not listed in subband triplets.
+func (ie *CountryIE) MaxPower(bandRange string, channel uint8) (int8, bool) {
+	for _, t := range ie.Triplets {
+		if t.Band().Range() == bandRange && t.Contains(channel) {
+			return t.MaxPower, true
+		}
+	}
+
+	return 0, false
+}
+
+// Returns country code with environment, e.g. DE (indoor).
+func (ie *CountryIE) String() string {
+	switch ie.Environment {
+	case 'I':
+		return ie.Country + " (indoor)"
+	case 'O':
+		return ie.Country + " (outdoor)"
+	default:
+		return ie.Country
+	}
+}
+
+// Returns subband triplets as channel ranges with power, e.g. 1-13:20dBm 36-48:23dBm.
+func (ie *CountryIE) TripletsString() string {
+	ranges := make([]string, len(ie.Triplets))
+	for i, t := range ie.Triplets {
+		ranges[i] = fmt.Sprintf("%d-%d:%ddBm", t.FirstChannel, t.LastChannel(), t.MaxPower)
+	}
+
+	return strings.Join(ranges, " ")
+}
+
+// Returns band of triplet channels, 2.4GHz or 5GHz.
+func (t CountryTriplet) Band() Band {
+	return GetBandByChan(t.FirstChannel)
+}
+
+// Returns step between channel numbers: 1 in 2.4GHz band and 4 in 5GHz band.
+func (t CountryTriplet) step() uint8 {
+	if t.Band() == ISM {
+		return 1
+	}
+
+	return 4 //nolint:gomnd // ignore
+}
+
+// Returns the last channel of triplet, malformed triplet exceeding channel numbers ends with the last one.
+func (t CountryTriplet) LastChannel() uint8 {
+	if t.NumChannels == 0 {
+		return t.FirstChannel
+	}
+
+	return uint8(min(int(t.FirstChannel)+int(t.NumChannels-1)*int(t.step()), math.MaxUint8))
+}
+
+// Returns true if channel belongs to triplet.
+func (t CountryTriplet) Contains(channel uint8) bool {
+	return channel >= t.FirstChannel && channel <= t.LastChannel() && (channel-t.FirstChannel)%t.step() == 0
+}
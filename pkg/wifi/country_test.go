@@ -0,0 +1,72 @@
+package wifi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/gopacket/layers"
+)
+
+func TestDiscoverCountryIE(t *testing.T) {
+	tests := []struct {
+		name string
+		info []byte
+		want CountryIE
+	}{
+		{"empty", nil, CountryIE{}},
+		{"truncated country string", []byte("DE"), CountryIE{}},
+		{"country only", []byte("DEI"), CountryIE{Country: "DE", Environment: 'I'}},
+		{"subband triplets", []byte{'D', 'E', ' ', 1, 13, 20, 36, 4, 23},
+			CountryIE{Country: "DE", Environment: ' ', Triplets: []CountryTriplet{{1, 13, 20}, {36, 4, 23}}}},
+		{"truncated triplet is ignored", []byte{'D', 'E', ' ', 1, 13, 20, 36, 4},
+			CountryIE{Country: "DE", Environment: ' ', Triplets: []CountryTriplet{{1, 13, 20}}}},
+		{"subband triplets of operating class are ignored",
+			[]byte{'U', 'S', 'O', 36, 8, 23, 201, 131, 0, 1, 93, 24},
+			CountryIE{Country: "US", Environment: 'O', Triplets: []CountryTriplet{{36, 8, 23}}}},
+		{"negative power", []byte{'J', 'P', ' ', 1, 14, 0xfe},
+			CountryIE{Country: "JP", Environment: ' ', Triplets: []CountryTriplet{{1, 14, -2}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ie InformationElements
+			ie.discoverCountryIE(&layers.Dot11InformationElement{ID: layers.Dot11InformationElementIDCountryInfo, Info: tt.info})
+			if !reflect.DeepEqual(ie.CountryIE, tt.want) {
+				t.Errorf("got %+v, want %+v", ie.CountryIE, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountryTriplet(t *testing.T) {
+	tests := []struct {
+		name     string
+		triplet  CountryTriplet
+		last     uint8
+		contains []uint8
+		excludes []uint8
+	}{
+		{"2.4GHz", CountryTriplet{1, 13, 20}, 13, []uint8{1, 6, 13}, []uint8{0, 14, 36}},
+		{"5GHz", CountryTriplet{36, 4, 23}, 48, []uint8{36, 40, 48}, []uint8{34, 38, 52}},
+		{"zero channels", CountryTriplet{149, 0, 30}, 149, []uint8{149}, []uint8{153}},
+		{"malformed count exceeds channel numbers", CountryTriplet{149, 100, 30}, 255, []uint8{149, 253}, []uint8{3, 254}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.triplet.LastChannel(); got != tt.last {
+				t.Errorf("last channel %d, want %d", got, tt.last)
+			}
+			for _, ch := range tt.contains {
+				if !tt.triplet.Contains(ch) {
+					t.Errorf("channel %d is not contained", ch)
+				}
+			}
+			for _, ch := range tt.excludes {
+				if tt.triplet.Contains(ch) {
+					t.Errorf("channel %d is contained", ch)
+				}
+			}
+		})
+	}
+}
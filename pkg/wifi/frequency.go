@@ -0,0 +1,42 @@
+package wifi
+
+import "wfmon/pkg/wifi/chanfreq"
+
+// Returns channel number by channel center frequency in MHz, 0 if frequency is out of known bands.
+func FrequencyToChannel(freq int) uint8 {
+	return chanfreq.ToChannel(freq)
+}
+
+// Returns channel center frequency in MHz by channel number and band, 0 if band is unknown.
+// Channel numbers of 2.4GHz, 5GHz and 6GHz bands overlap, thus band is required.
+func ChannelToFrequency(channel uint8, band Band) int {
+	return chanfreq.ToFrequency(channel, band.chanRange())
+}
+
+// Returns band range of channel numbering.
+func (b Band) chanRange() chanfreq.Range {
+	switch {
+	case b == ISM:
+		return chanfreq.Range2GHz
+	case b == PublicSafety:
+		return chanfreq.Range4GHz
+	case b.Is6GHz():
+		return chanfreq.Range6GHz
+	case b != Unknown:
+		return chanfreq.Range5GHz
+	default:
+		return chanfreq.RangeUnknown
+	}
+}
+
+// Returns true if frame advertising channel was received on frequency of another channel,
+// e.g. beacon of adjacent channel leaked to tuned one.
+// Duplicated 6GHz beacons are expected on every 20MHz channel of BSS.
+func OffChannel(channel uint8, freq int, duplicateBeacon bool) bool {
+	tuned := FrequencyToChannel(freq)
+	if channel == 0 || tuned == 0 || duplicateBeacon {
+		return false
+	}
+
+	return tuned != channel
+}
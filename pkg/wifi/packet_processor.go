@@ -3,9 +3,10 @@ package wifi
 // https://mrncciew.com/2014/10/04/my-cwap-study-notes/
 
 import (
+	"encoding/binary"
 	"net"
-	"regexp"
 	log "wfmon/pkg/logger"
+	"wfmon/pkg/utils/cmp"
 
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
@@ -29,9 +30,16 @@ type mgmtLayers = interface {
 		layers.Dot11MgmtReassociationResp
 }
 
+// Layers of requests transmitted by client stations.
+type clientLayers = interface {
+	layers.Dot11MgmtProbeReq |
+		layers.Dot11MgmtAssociationReq |
+		layers.Dot11MgmtReassociationReq
+}
+
 // Overall supported layers constraint for tryLayer func.
 type supportedLayers = interface {
-	layers.RadioTap | layers.Dot11 | mgmtLayers
+	layers.RadioTap | layers.Dot11 | clientLayers | mgmtLayers
 }
 
 // Tries to extract required layer type from packet and cast it to gopacket.Layer structure.
@@ -90,7 +98,7 @@ func (p *PacketDiscover) DiscoverDot11Frame() *Dot11Frame {
 	// ToDS == 0 and FromDS == 1
 	case !dot11.Flags.ToDS() && dot11.Flags.FromDS():
 		frame = NewDot11Frame(dot11.Type,
-			dot11.Address3, dot11.Address1, net.HardwareAddr{}, dot11.Address1, dot11.Address1)
+			dot11.Address3, dot11.Address1, dot11.Address2, dot11.Address1, dot11.Address2)
 	// ToDS == 1 and FromDS == 0
 	case dot11.Flags.ToDS() && !dot11.Flags.FromDS():
 		frame = NewDot11Frame(dot11.Type,
@@ -109,6 +117,8 @@ func (p *PacketDiscover) DiscoverDot11Frame() *Dot11Frame {
 		frame.RadioFrame = *radio
 	}
 
+	frame.Timestamp = p.Metadata().Timestamp
+
 	return frame
 }
 
@@ -127,7 +137,7 @@ func (p *PacketDiscover) DiscoverIEs() *InformationElements {
 			continue
 		}
 
-		//nolint:exhaustive // process only 3 IE
+		//nolint:exhaustive // process only known IE
 		switch dot11info.ID {
 		// case layers.Dot11InformationElementIDSSID:
 		// 	if ie == nil {
@@ -155,6 +165,70 @@ func (p *PacketDiscover) DiscoverIEs() *InformationElements {
 				ie = &InformationElements{}
 			}
 			ie.discoverDSSetIE(dot11info)
+
+		// Security elements are advertised in
+		// Beacon, Probe Response & (Re)Association Response frames.
+		// https://mrncciew.com/2014/08/21/cwap-802-11-security-rsn-information-element/
+		case layers.Dot11InformationElementIDRSNInfo:
+			if ie == nil {
+				ie = &InformationElements{}
+			}
+			ie.discoverRSNIE(dot11info)
+
+		// Vendor specific elements: WPA, Wi-Fi Protected Setup and Wi-Fi Direct.
+		case layers.Dot11InformationElementIDVendor:
+			if ie == nil {
+				ie = &InformationElements{}
+			}
+			ie.discoverVendorIE(dot11info)
+
+		// Rates and capabilities elements determine PHY generations of BSS.
+		// https://mrncciew.com/2014/11/04/cwap-ht-capabilities-ie/
+		case layers.Dot11InformationElementIDRates, layers.Dot11InformationElementIDESRates:
+			if ie == nil {
+				ie = &InformationElements{}
+			}
+			ie.discoverRatesIE(dot11info)
+
+		case layers.Dot11InformationElementIDHTCapabilities:
+			if ie == nil {
+				ie = &InformationElements{}
+			}
+			ie.discoverHTCapabilitiesIE(dot11info)
+
+		case layers.Dot11InformationElementIDVHTCapabilities:
+			if ie == nil {
+				ie = &InformationElements{}
+			}
+			ie.discoverVHTCapabilitiesIE(dot11info)
+
+		// Country element is advertised in Beacon & Probe Response frames
+		// by APs supporting 802.11d and mandatory in 5GHz and 6GHz.
+		case layers.Dot11InformationElementIDCountryInfo:
+			if ie == nil {
+				ie = &InformationElements{}
+			}
+			ie.discoverCountryIE(dot11info)
+
+		// BSS Load element is advertised by QoS APs in Beacon & Probe Response frames.
+		case layers.Dot11InformationElementIDQBSSLoadElem:
+			if ie == nil {
+				ie = &InformationElements{}
+			}
+			ie.discoverBSSLoadIE(dot11info)
+
+		// Extended Capabilities element declares UTF-8 encoding of SSID.
+		case layers.Dot11InformationElementIDExtCapability:
+			if ie == nil {
+				ie = &InformationElements{}
+			}
+			ie.discoverExtCapabilitiesIE(dot11info)
+
+		case elementIDExtension:
+			if ie == nil {
+				ie = &InformationElements{}
+			}
+			ie.discoverExtensionIE(dot11info)
 		}
 	}
 
@@ -162,9 +236,62 @@ func (p *PacketDiscover) DiscoverIEs() *InformationElements {
 		ie.Channel = ie.PrimaryChannel
 	}
 
+	// 6GHz BSS advertises neither DS Set nor HT/VHT Operation
+	if ie != nil && ie.Channel == 0 && ie.HE6GHzInfoValid {
+		ie.apply6GHzOperation()
+	}
+
 	return ie
 }
 
+// Applies 6GHz Operation Information of HE Operation and EHT Operation
+// as primary channel, secondary channel offset and VHT channel width and centers.
+func (ie *InformationElements) apply6GHzOperation() {
+	const (
+		width40          = 1
+		width80          = 2
+		width160         = 3
+		eht320           = 4
+		segmentsDistance = 8 // 160MHz center is 8 channels away of 80MHz center
+	)
+
+	op := ie.HE6GHzOperationIE
+	ie.Channel = op.HE6GHzPrimaryChannel
+
+	// 20MHz or 40MHz
+	if op.HE6GHzChannelWidth == width40 {
+		ie.SecondaryChannelOffset = uint8(cmp.Nvl(op.HE6GHzChannelCenter0 > op.HE6GHzPrimaryChannel, SCA, SCB))
+	}
+
+	switch {
+	case ie.EHTOperationInfoValid && ie.EHTChannelWidth == eht320:
+		ie.VHTOperationIE = VHTOperationIE{
+			ChannelWidth:          uint8(WidthOperation320),
+			ChannelCenterSegment0: ie.EHTChannelCenterSeg1,
+		}
+	case op.HE6GHzChannelWidth == width160:
+		diff := int(op.HE6GHzChannelCenter1) - int(op.HE6GHzChannelCenter0)
+		if diff == segmentsDistance || diff == -segmentsDistance {
+			// contiguous 160MHz
+			ie.VHTOperationIE = VHTOperationIE{
+				ChannelWidth:          uint8(WidthOperation160),
+				ChannelCenterSegment0: op.HE6GHzChannelCenter1,
+			}
+		} else {
+			ie.VHTOperationIE = VHTOperationIE{
+				ChannelWidth:          uint8(WidthOperation80And80),
+				ChannelCenterSegment0: op.HE6GHzChannelCenter0,
+				ChannelCenterSegment1: op.HE6GHzChannelCenter1,
+			}
+		}
+	case op.HE6GHzChannelWidth == width80:
+		ie.VHTOperationIE = VHTOperationIE{
+			ChannelWidth:          uint8(WidthOperation80),
+			ChannelCenterSegment0: op.HE6GHzChannelCenter0,
+		}
+	}
+}
+
 // Discovers SSID from Information Element.
 // func (ie *InformationElements) discoverSSIDIE(dot11info *layers.Dot11InformationElement) {
 // 	if len(dot11info.Info) > 0 {
@@ -208,6 +335,358 @@ func (ie *InformationElements) discoverDSSetIE(dot11info *layers.Dot11Informatio
 	}
 }
 
+// Discovers Supported Rates or Extended Supported Rates from Information Element.
+func (ie *InformationElements) discoverRatesIE(dot11info *layers.Dot11InformationElement) {
+	ie.Rates = append(ie.Rates, dot11info.Info...)
+}
+
+// Discovers HT Capabilities from Information Element.
+// Layout: HT capabilities info(2) A-MPDU params(1) supported MCS set(16) ...
+func (ie *InformationElements) discoverHTCapabilitiesIE(dot11info *layers.Dot11InformationElement) {
+	const (
+		infoLen     = 2
+		mcsOffset   = 3
+		mcsRxStream = 4 // Rx MCS bitmask bytes, one per spatial stream
+	)
+
+	// check malformed packet
+	if len(dot11info.Info) < infoLen {
+		return
+	}
+
+	ie.HTCapabilitiesIE = HTCapabilitiesIE{
+		HTSupported: true,
+		HTInfo:      binary.LittleEndian.Uint16(dot11info.Info[0:infoLen]),
+	}
+
+	if len(dot11info.Info) >= mcsOffset+mcsRxStream {
+		for _, mcs := range dot11info.Info[mcsOffset : mcsOffset+mcsRxStream] {
+			if mcs != 0 {
+				ie.HTStreams++
+			}
+		}
+	}
+}
+
+// Discovers VHT Capabilities from Information Element.
+// Layout: VHT capabilities info(4) Rx MCS map(2) Rx highest rate(2) Tx MCS map(2) Tx highest rate(2).
+func (ie *InformationElements) discoverVHTCapabilitiesIE(dot11info *layers.Dot11InformationElement) {
+	const (
+		infoLen      = 4
+		mcsMapLen    = 2
+		maxStreams   = 8
+		notSupported = 0b11
+	)
+
+	// check malformed packet
+	if len(dot11info.Info) < infoLen {
+		return
+	}
+
+	ie.VHTCapabilitiesIE = VHTCapabilitiesIE{
+		VHTSupported: true,
+		VHTInfo:      binary.LittleEndian.Uint32(dot11info.Info[0:infoLen]),
+	}
+
+	if len(dot11info.Info) >= infoLen+mcsMapLen {
+		// 2 bits per spatial stream
+		mcsMap := binary.LittleEndian.Uint16(dot11info.Info[infoLen : infoLen+mcsMapLen])
+		for i := 0; i < maxStreams; i++ {
+			if (mcsMap>>(2*i))&notSupported != notSupported {
+				ie.VHTStreams++
+			}
+		}
+	}
+}
+
+// Discovers Extended Capabilities from Information Element.
+// Layout: capabilities bit field of variable length, UTF-8 SSID is bit 48.
+func (ie *InformationElements) discoverExtCapabilitiesIE(dot11info *layers.Dot11InformationElement) {
+	const (
+		utf8SSIDOctet = 6
+		utf8SSIDBit   = 0x01
+	)
+
+	if len(dot11info.Info) > utf8SSIDOctet {
+		ie.UTF8SSID = dot11info.Info[utf8SSIDOctet]&utf8SSIDBit != 0
+	}
+}
+
+// Discovers Country from Information Element.
+// Layout: country string(3) triplets(3)... optional padding(1).
+// Triplet with first byte 201 or greater is an operating triplet, otherwise a subband one.
+// Subband triplets following an operating triplet belong to its operating class, e.g. 6GHz channels,
+// so only subband triplets preceding the first operating triplet are kept.
+func (ie *InformationElements) discoverCountryIE(dot11info *layers.Dot11InformationElement) {
+	const (
+		countryLen           = 2
+		countryStringLen     = 3
+		tripletLen           = 3
+		operatingExtensionID = 201
+	)
+
+	// check malformed packet
+	if len(dot11info.Info) < countryStringLen {
+		return
+	}
+
+	ie.CountryIE = CountryIE{
+		Country:     string(dot11info.Info[:countryLen]),
+		Environment: dot11info.Info[countryLen],
+	}
+
+	for i := countryStringLen; i+tripletLen <= len(dot11info.Info); i += tripletLen {
+		triplet := dot11info.Info[i : i+tripletLen]
+		if triplet[0] >= operatingExtensionID {
+			break
+		}
+
+		ie.Triplets = append(ie.Triplets, CountryTriplet{
+			FirstChannel: triplet[0],
+			NumChannels:  triplet[1],
+			MaxPower:     int8(triplet[2]),
+		})
+	}
+}
+
+// Discovers BSS Load from Information Element.
+// Layout: station count(2) channel utilization(1) available admission capacity(2).
+func (ie *InformationElements) discoverBSSLoadIE(dot11info *layers.Dot11InformationElement) {
+	const (
+		bssLoadLen  = 5
+		utilOffset  = 2
+		admitOffset = 3
+	)
+
+	// check malformed packet
+	if len(dot11info.Info) < bssLoadLen {
+		return
+	}
+
+	ie.BSSLoadIE = BSSLoadIE{
+		BSSLoadValid:       true,
+		StationCount:       binary.LittleEndian.Uint16(dot11info.Info[0:utilOffset]),
+		ChannelUtilization: dot11info.Info[utilOffset],
+		AdmissionCapacity:  binary.LittleEndian.Uint16(dot11info.Info[admitOffset:bssLoadLen]),
+	}
+}
+
+// Discovers Element ID Extension from Information Element.
+// First byte of element body is extended element ID.
+func (ie *InformationElements) discoverExtensionIE(dot11info *layers.Dot11InformationElement) {
+	// check malformed packet
+	if len(dot11info.Info) < 1 {
+		return
+	}
+
+	body := dot11info.Info[1:]
+
+	switch dot11info.Info[0] {
+	case extIDHECapabilities:
+		ie.HESupported = true
+
+	case extIDHEOperation:
+		ie.discoverHEOperationIE(body)
+
+	case extIDEHTCapability:
+		ie.EHTSupported = true
+
+	case extIDEHTOperation:
+		ie.discoverEHTOperationIE(body)
+	}
+}
+
+// Discovers HE Operation from Element ID Extension body.
+// Layout: HE operation params(3) BSS color info(1) basic HE-MCS and NSS set(2)
+// [VHT operation info(3)] [max co-hosted BSSID indicator(1)] [6GHz operation info(5)].
+func (ie *InformationElements) discoverHEOperationIE(body []byte) {
+	const (
+		paramsLen          = 3
+		colorLen           = 1
+		mcsLen             = 2
+		vhtInfoLen         = 3
+		coHostedLen        = 1
+		he6GHzInfoLen      = 5
+		colorMask          = 0b00111111
+		vhtInfoPresent     = 1 << 14
+		coHostedPresent    = 1 << 15
+		he6GHzInfoPresent  = 1 << 17
+		widthMask          = 0b00000011
+		duplicateBeaconBit = 0b00000100
+	)
+
+	// check malformed packet
+	if len(body) < paramsLen+colorLen {
+		return
+	}
+
+	ie.HEOperation = uint32(body[0]) | uint32(body[1])<<8 | uint32(body[2])<<16
+	ie.BSSColor = body[paramsLen] & colorMask
+
+	if ie.HEOperation&he6GHzInfoPresent == 0 {
+		return
+	}
+
+	offset := paramsLen + colorLen + mcsLen
+	if ie.HEOperation&vhtInfoPresent != 0 {
+		offset += vhtInfoLen
+	}
+	if ie.HEOperation&coHostedPresent != 0 {
+		offset += coHostedLen
+	}
+
+	// check malformed packet
+	if len(body) < offset+he6GHzInfoLen {
+		return
+	}
+
+	info := body[offset:]
+	ie.HE6GHzOperationIE = HE6GHzOperationIE{
+		HE6GHzInfoValid:       true,
+		HE6GHzPrimaryChannel:  info[0],
+		HE6GHzChannelWidth:    info[1] & widthMask,
+		HE6GHzDuplicateBeacon: info[1]&duplicateBeaconBit != 0,
+		HE6GHzChannelCenter0:  info[2],
+		HE6GHzChannelCenter1:  info[3],
+	}
+}
+
+// Discovers EHT Operation from Element ID Extension body.
+// Layout: EHT operation params(1) basic EHT-MCS and NSS set(4) [control(1) CCFS0(1) CCFS1(1) ...].
+func (ie *InformationElements) discoverEHTOperationIE(body []byte) {
+	const (
+		paramsLen   = 1
+		mcsLen      = 4
+		infoLen     = 3
+		infoPresent = 0b00000001
+		widthMask   = 0b00000111
+	)
+
+	// check malformed packet
+	if len(body) < paramsLen+mcsLen+infoLen || body[0]&infoPresent == 0 {
+		return
+	}
+
+	info := body[paramsLen+mcsLen:]
+	ie.EHTOperationInfoValid = true
+	ie.EHTChannelWidth = info[0] & widthMask
+	ie.EHTChannelCenterSeg0 = info[1]
+	ie.EHTChannelCenterSeg1 = info[2]
+}
+
+// Discovers RSN from Information Element.
+func (ie *InformationElements) discoverRSNIE(dot11info *layers.Dot11InformationElement) {
+	if rsn, ok := decodeRSN(dot11info.Info, rsnOUI); ok {
+		ie.RSN = rsn
+	}
+}
+
+// Discovers known vendor specific Information Elements by decoders registered for OUI and vendor type.
+func (ie *InformationElements) discoverVendorIE(dot11info *layers.Dot11InformationElement) {
+	// gopacket keeps OUI and vendor specific type together
+	const ouiAndTypeLen = 4
+	if len(dot11info.OUI) < ouiAndTypeLen {
+		return
+	}
+
+	oui := uint32(dot11info.OUI[0])<<16 | uint32(dot11info.OUI[1])<<8 | uint32(dot11info.OUI[2])
+	if decode, found := vendorDecoders[vendorKey{oui: oui, ouiType: dot11info.OUI[3]}]; found {
+		decode(ie, dot11info.Info)
+	}
+}
+
+// Decodes RSN (or WPA) element body.
+// Layout: version(2) group cipher(4) pairwise count(2) pairwise list(4*n) AKM count(2) AKM list(4*m) capabilities(2).
+// All fields after version are optional, truncated element keeps defaults.
+// Suites are expected within given OUI, otherwise marked as vendor specific.
+func decodeRSN(info []byte, oui uint32) (RSNIE, bool) {
+	const (
+		versionLen      = 2
+		suiteLen        = 4
+		countLen        = 2
+		capabilitiesLen = 2
+		mfpRequired     = 1 << 6
+		mfpCapable      = 1 << 7
+	)
+
+	// malformed element
+	if len(info) < versionLen {
+		return RSNIE{}, false
+	}
+
+	// default suites when element is truncated
+	defaultCipher := CipherCCMP
+	if oui == wpaOUI {
+		defaultCipher = CipherTKIP
+	}
+
+	rsn := RSNIE{
+		Version:         binary.LittleEndian.Uint16(info[0:versionLen]),
+		GroupCipher:     defaultCipher,
+		PairwiseCiphers: []CipherSuite{defaultCipher},
+		AKMSuites:       []AKMSuite{AKM8021X},
+	}
+	data := info[versionLen:]
+
+	var suite = func(b []byte) (uint8, bool) {
+		if uint32(b[0])<<16|uint32(b[1])<<8|uint32(b[2]) != oui {
+			return 0, false
+		}
+		return b[3], true
+	}
+
+	var suites = func() [][]byte {
+		if len(data) < countLen {
+			return nil
+		}
+		cnt := int(binary.LittleEndian.Uint16(data[0:countLen]))
+		data = data[countLen:]
+
+		// count of malformed element might exceed suites present
+		list := make([][]byte, 0, min(cnt, len(data)/suiteLen))
+		for i := 0; i < cnt && len(data) >= suiteLen; i++ {
+			list = append(list, data[:suiteLen])
+			data = data[suiteLen:]
+		}
+		return list
+	}
+
+	// group cipher
+	if len(data) < suiteLen {
+		return rsn, true
+	}
+	t, ok := suite(data[:suiteLen])
+	rsn.GroupCipher = cmp.Nvl(ok, CipherSuite(t), CipherVendor)
+	data = data[suiteLen:]
+
+	// pairwise ciphers
+	if list := suites(); list != nil {
+		rsn.PairwiseCiphers = make([]CipherSuite, len(list))
+		for i, b := range list {
+			t, ok := suite(b)
+			rsn.PairwiseCiphers[i] = cmp.Nvl(ok, CipherSuite(t), CipherVendor)
+		}
+	}
+
+	// authentication and key management suites
+	if list := suites(); list != nil {
+		rsn.AKMSuites = make([]AKMSuite, len(list))
+		for i, b := range list {
+			t, ok := suite(b)
+			rsn.AKMSuites[i] = cmp.Nvl(ok, AKMSuite(t), AKMVendor)
+		}
+	}
+
+	// RSN capabilities
+	if len(data) >= capabilitiesLen {
+		capabilities := binary.LittleEndian.Uint16(data[0:capabilitiesLen])
+		rsn.MFPRequired = capabilities&mfpRequired != 0
+		rsn.MFPCapable = capabilities&mfpCapable != 0
+	}
+
+	return rsn, true
+}
+
 // Discovers wifi frame.
 // Then traverses packet and discovers a management frame that contains Information Elements.
 func (p *PacketDiscover) DiscoverMgmtFrame() *MgmtFrame {
@@ -228,10 +707,12 @@ func (p *PacketDiscover) DiscoverMgmtFrame() *MgmtFrame {
 
 			if ie := p.DiscoverIEs(); ie != nil {
 				frame.InformationElements = *ie
-				// if len(ie.SSID) > 0 {
-				// frame.SSID = ie.SSID
-				// }
 			}
+			// encoding of SSID is declared by Extended Capabilities
+			if !frame.HiddenSSID {
+				frame.SSID = DecodeSSID(frame.rawSSID, frame.UTF8SSID)
+			}
+			frame.rawSSID = nil
 
 			return frame
 		}
@@ -257,11 +738,11 @@ func (p *PacketDiscover) DiscoverMgmtBeaconFrame() *MgmtFrame {
 	if ssIDLen > len(beacon.BaseLayer.Contents)-14 {
 		return nil
 	}
-	ssID := string(beacon.BaseLayer.Contents[14 : 14+ssIDLen])
-	re := regexp.MustCompile(`[[:cntrl:]]`)
-	ssID = re.ReplaceAllString(ssID, "?")
+	ssID := beacon.BaseLayer.Contents[14 : 14+ssIDLen]
 	frame := &MgmtFrame{
-		SSID: ssID,
+		CapabilityInfo: CapabilityInfo(beacon.Flags),
+		HiddenSSID:     HiddenSSID(ssID),
+		rawSSID:        ssID,
 	}
 
 	return frame
@@ -286,11 +767,11 @@ func (p *PacketDiscover) DiscoverMgmtProbeRespFrame() *MgmtFrame {
 	}
 
 	ssIDLen = min(ssIDLen, len(resp.BaseLayer.Contents)-14)
-	ssID := string(resp.BaseLayer.Contents[14 : 14+ssIDLen])
-	re := regexp.MustCompile(`[[:cntrl:]]`)
-	ssID = re.ReplaceAllString(ssID, "?")
+	ssID := resp.BaseLayer.Contents[14 : 14+ssIDLen]
 	frame := &MgmtFrame{
-		SSID: ssID,
+		CapabilityInfo: CapabilityInfo(resp.Flags),
+		HiddenSSID:     HiddenSSID(ssID),
+		rawSSID:        ssID,
 	}
 
 	return frame
@@ -299,21 +780,127 @@ func (p *PacketDiscover) DiscoverMgmtProbeRespFrame() *MgmtFrame {
 // Discovers Management Association Response frame from packet.
 // https://mrncciew.com/2014/10/28/802-11-mgmt-association-reqresponse/
 func (p *PacketDiscover) DiscoverMgmtAssociationRespFrame() *MgmtFrame {
-	_, ok := tryLayer[layers.Dot11MgmtAssociationResp](p, layers.LayerTypeDot11MgmtAssociationResp)
+	resp, ok := tryLayer[layers.Dot11MgmtAssociationResp](p, layers.LayerTypeDot11MgmtAssociationResp)
 	if !ok {
 		return nil
 	}
 
-	return &MgmtFrame{}
+	return &MgmtFrame{
+		CapabilityInfo: CapabilityInfo(resp.CapabilityInfo),
+	}
 }
 
 // Discovers Management Reassociation Response frame from packet.
 // https://mrncciew.com/2014/10/28/cwap-reassociation-reqresponse/
 func (p *PacketDiscover) DiscoverMgmtReassociationRespFrame() *MgmtFrame {
-	_, ok := tryLayer[layers.Dot11MgmtReassociationResp](p, layers.LayerTypeDot11MgmtReassociationResp)
+	resp, ok := tryLayer[layers.Dot11MgmtReassociationResp](p, layers.LayerTypeDot11MgmtReassociationResp)
 	if !ok {
 		return nil
 	}
 
-	return &MgmtFrame{}
+	// gopacket does not decode fixed fields of reassociation response
+	frame := &MgmtFrame{}
+	if len(resp.BaseLayer.Contents) >= 2 {
+		frame.CapabilityInfo = CapabilityInfo(binary.LittleEndian.Uint16(resp.BaseLayer.Contents[0:2]))
+	}
+
+	return frame
+}
+
+// Discovers frame of client station from probe request, (re)association request or data frame.
+// Data frames between stations of IBSS and within WDS are skipped.
+// https://mrncciew.com/2014/09/28/cwap-mac-headeraddresses/
+func (p *PacketDiscover) DiscoverClientFrame() *ClientFrame {
+	var dot11 *Dot11Frame
+	if dot11 = p.DiscoverDot11Frame(); dot11 == nil {
+		return nil
+	}
+
+	frame := &ClientFrame{Dot11Frame: *dot11}
+
+	switch {
+	case dot11.Dot11Type == layers.Dot11TypeMgmtProbeReq:
+		frame.Station = dot11.TransmitterAddress
+		frame.FromClient = true
+		frame.ProbedSSID = p.discoverProbedSSID()
+		// probe request is not addressed to a BSS
+		frame.BSSID = nil
+
+	case dot11.Dot11Type == layers.Dot11TypeMgmtAssociationReq,
+		dot11.Dot11Type == layers.Dot11TypeMgmtReassociationReq:
+		frame.Station = dot11.TransmitterAddress
+		frame.FromClient = true
+		frame.AssociationSSID = p.discoverAssociationSSID()
+
+	case dot11.Dot11Type.MainType() == layers.Dot11TypeData:
+		layer, ok := tryLayer[layers.Dot11](p, layers.LayerTypeDot11)
+		if !ok {
+			return nil
+		}
+
+		switch toDS, fromDS := layer.Flags.ToDS(), layer.Flags.FromDS(); {
+		case toDS && !fromDS:
+			frame.Station = dot11.TransmitterAddress
+			frame.FromClient = true
+		case fromDS && !toDS:
+			frame.Station = dot11.ReceiverAddress
+		default:
+			return nil
+		}
+
+	default:
+		return nil
+	}
+
+	// group addressed frames have no client
+	if len(frame.Station) == 0 || frame.Station[0]&0x01 != 0 {
+		return nil
+	}
+
+	return frame
+}
+
+// Returns SSID element of probe request, empty for wildcard SSID.
+// gopacket does not decode elements of probe request, so they are walked through layer contents.
+func (p *PacketDiscover) discoverProbedSSID() string {
+	req, ok := tryLayer[layers.Dot11MgmtProbeReq](p, layers.LayerTypeDot11MgmtProbeReq)
+	if !ok {
+		return ""
+	}
+
+	return elementSSID(req.BaseLayer.Contents)
+}
+
+// Returns SSID element of association or reassociation request.
+// Station requests association with network by name, so it reveals name of hidden network.
+func (p *PacketDiscover) discoverAssociationSSID() string {
+	if req, ok := tryLayer[layers.Dot11MgmtAssociationReq](p, layers.LayerTypeDot11MgmtAssociationReq); ok {
+		return elementSSID(req.Payload)
+	}
+	if req, ok := tryLayer[layers.Dot11MgmtReassociationReq](p, layers.LayerTypeDot11MgmtReassociationReq); ok {
+		return elementSSID(req.Payload)
+	}
+
+	return ""
+}
+
+// Walks elements and returns SSID element, empty if it is missing, hidden or elements are malformed.
+func elementSSID(data []byte) string {
+	for len(data) >= 2 {
+		id, length := layers.Dot11InformationElementID(data[0]), int(data[1])
+		// malformed element
+		if length > len(data)-2 {
+			return ""
+		}
+
+		if id == layers.Dot11InformationElementIDSSID {
+			if HiddenSSID(data[2 : 2+length]) {
+				return ""
+			}
+			return DecodeSSID(data[2:2+length], false).String()
+		}
+		data = data[2+length:]
+	}
+
+	return ""
 }
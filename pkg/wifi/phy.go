@@ -0,0 +1,106 @@
+package wifi
+
+import "strings"
+
+// https://mrncciew.com/2014/10/08/802-11-mgmt-beacon-frame/
+// https://mrncciew.com/2014/11/04/cwap-ht-capabilities-ie/
+
+// Element ID Extension and extended element IDs.
+const (
+	elementIDExtension  = 255
+	extIDHECapabilities = 35
+	extIDHEOperation    = 36
+	extIDEHTOperation   = 106
+	extIDEHTCapability  = 108
+)
+
+// PHY generations bitmask.
+// Higher bit means newer generation, so the value can be used for sorting.
+type PHY uint8
+
+const (
+	PHYb  PHY = 1 << 0 // 802.11b, DSSS/HR-DSSS (2.4GHz)
+	PHYa  PHY = 1 << 1 // 802.11a, OFDM (5GHz)
+	PHYg  PHY = 1 << 2 // 802.11g, ERP-OFDM (2.4GHz)
+	PHYn  PHY = 1 << 3 // 802.11n, HT (Wi-Fi 4)
+	PHYac PHY = 1 << 4 // 802.11ac, VHT (Wi-Fi 5)
+	PHYax PHY = 1 << 5 // 802.11ax, HE (Wi-Fi 6/6E)
+	PHYbe PHY = 1 << 6 // 802.11be, EHT (Wi-Fi 7)
+)
+
+// Returns string presentation, e.g. b/g/n or a/n/ac/ax.
+func (p PHY) String() string {
+	names := []string{}
+	for _, g := range []struct {
+		flag PHY
+		name string
+	}{
+		{PHYb, "b"},
+		{PHYa, "a"},
+		{PHYg, "g"},
+		{PHYn, "n"},
+		{PHYac, "ac"},
+		{PHYax, "ax"},
+		{PHYbe, "be"},
+	} {
+		if p&g.flag != 0 {
+			names = append(names, g.name)
+		}
+	}
+
+	return strings.Join(names, "/")
+}
+
+// Returns true if only 802.11b rates are supported.
+func (p PHY) LegacyOnly() bool {
+	return p == PHYb
+}
+
+// Determines PHY generations supported by BSS from rates and capabilities elements.
+func GetPHY(frame Frame) PHY {
+	var phy PHY
+
+	var dsss, ofdm bool
+	for _, rate := range frame.Rates {
+		//nolint:gomnd // ignore
+		switch rate & 0x7f {
+		// 1, 2, 5.5, 11 Mbps
+		case 2, 4, 11, 22:
+			dsss = true
+		// 6, 9, 12, 18, 24, 36, 48, 54 Mbps
+		case 12, 18, 24, 36, 48, 72, 96, 108:
+			ofdm = true
+		}
+	}
+
+	band := GetBand(frame.Frequency, frame.Channel)
+
+	switch {
+	case band == ISM:
+		if dsss {
+			phy |= PHYb
+		}
+		if ofdm {
+			phy |= PHYg
+		}
+	// 6GHz allows non-HT duplicate only, it is not 802.11a
+	case band != Unknown && !band.Is6GHz() && ofdm:
+		phy |= PHYa
+	}
+
+	if frame.HTSupported {
+		phy |= PHYn
+	}
+	// VHT is defined for 5GHz only
+	if frame.VHTSupported && band != ISM && !band.Is6GHz() {
+		phy |= PHYac
+	}
+	if frame.HESupported {
+		phy |= PHYax
+	}
+	if frame.EHTSupported {
+		phy |= PHYbe
+	}
+
+	return phy
+}
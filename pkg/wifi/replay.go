@@ -0,0 +1,226 @@
+package wifi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"wfmon/pkg/utils/cmp"
+	"wfmon/pkg/utils/vec"
+)
+
+// Replay speed multiplier of packets capture time.
+// Zero value means no pacing, packets are replayed as fast as they are read.
+type ReplaySpeed float64
+
+const (
+	ReplaySpeedMax     ReplaySpeed = 0
+	DefaultReplaySpeed ReplaySpeed = 1
+)
+
+// Returns supported replay speeds from slowest to fastest.
+func ReplaySpeeds() []ReplaySpeed {
+	//nolint:gomnd // ignore
+	return []ReplaySpeed{0.5, 1, 10, ReplaySpeedMax}
+}
+
+// Returns string presentation, e.g. 0.5x, 10x or max.
+func (s ReplaySpeed) String() string {
+	if s == ReplaySpeedMax {
+		return "max"
+	}
+
+	return fmt.Sprintf("%gx", float64(s))
+}
+
+// Parses replay speed from string, e.g. 0.5, 10x or max.
+func ParseReplaySpeed(s string) (ReplaySpeed, error) {
+	s = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), "x")
+	if s == ReplaySpeedMax.String() {
+		return ReplaySpeedMax, nil
+	}
+
+	val, err := strconv.ParseFloat(s, 64)
+	if err != nil || val < 0 {
+		return DefaultReplaySpeed, fmt.Errorf("invalid replay speed '%s'", s)
+	}
+
+	return ReplaySpeed(val), nil
+}
+
+// Paces packets by their capture timestamps.
+// Supports speed multiplier, pause/resume and step by one packet while paused.
+type Replay struct {
+	lock sync.Mutex
+
+	speed  ReplaySpeed
+	paused bool
+	steps  int
+
+	anchorCapture time.Time     // capture time of the packet the pacing is anchored to
+	anchorWall    time.Time     // wall-clock time the anchored packet was released at
+	last          time.Time     // capture time of the last released packet
+	changed       chan struct{} // closed on any state change to wake up waiting packet
+}
+
+func NewReplay(speed ReplaySpeed) *Replay {
+	return &Replay{
+		speed:   speed,
+		changed: make(chan struct{}),
+	}
+}
+
+// Blocks until a packet captured at @timestamp is due for replay, or context is done.
+func (r *Replay) Wait(ctx context.Context, timestamp time.Time) error {
+	for {
+		r.lock.Lock()
+		changed := r.changed
+
+		if r.paused {
+			if r.steps > 0 {
+				r.steps--
+				r.release(timestamp, true)
+				r.lock.Unlock()
+				return nil
+			}
+			r.lock.Unlock()
+
+			select {
+			case <-changed:
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if r.speed == ReplaySpeedMax || r.anchorCapture.IsZero() || timestamp.Before(r.anchorCapture) {
+			r.release(timestamp, !timestamp.Before(r.anchorCapture))
+			r.lock.Unlock()
+			return nil
+		}
+
+		due := r.anchorWall.Add(time.Duration(float64(timestamp.Sub(r.anchorCapture)) / float64(r.speed)))
+		r.lock.Unlock()
+
+		timer := time.NewTimer(time.Until(due))
+		select {
+		case <-timer.C:
+			r.lock.Lock()
+			r.release(timestamp, false)
+			r.lock.Unlock()
+			return nil
+		case <-changed:
+			timer.Stop()
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// Toggles pause, replay continues from the last released packet on resume.
+func (r *Replay) TogglePause() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.paused = !r.paused
+	r.steps = 0
+	r.notify()
+}
+
+// Releases one packet while paused.
+func (r *Replay) Step() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.paused {
+		return
+	}
+
+	r.steps++
+	r.notify()
+}
+
+// Switches to the next faster speed.
+func (r *Replay) Faster() {
+	r.shiftSpeed(1)
+}
+
+// Switches to the next slower speed.
+func (r *Replay) Slower() {
+	r.shiftSpeed(-1)
+}
+
+// Returns current speed.
+func (r *Replay) Speed() ReplaySpeed {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.speed
+}
+
+// Returns true if replay is paused.
+func (r *Replay) Paused() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.paused
+}
+
+// Returns replay state, e.g. 1x or 10x paused.
+func (r *Replay) String() string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.paused {
+		return fmt.Sprintf("%s paused", r.speed)
+	}
+
+	return r.speed.String()
+}
+
+func (r *Replay) shiftSpeed(delta int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// max speed is the fastest one
+	var rank = func(s ReplaySpeed) float64 {
+		return cmp.Nvl(s == ReplaySpeedMax, math.Inf(1), float64(s))
+	}
+
+	speeds := ReplaySpeeds()
+	if delta < 0 {
+		speeds = vec.Reverse(speeds)
+	}
+
+	for _, s := range speeds {
+		if (delta > 0 && rank(s) > rank(r.speed)) || (delta < 0 && rank(s) < rank(r.speed)) {
+			r.speed = s
+			r.notify()
+			return
+		}
+	}
+}
+
+// Marks packet as released and optionally re-anchors pacing to it.
+// Lock must be held.
+func (r *Replay) release(timestamp time.Time, anchor bool) {
+	r.last = timestamp
+	if anchor {
+		r.anchorCapture, r.anchorWall = timestamp, time.Now()
+	}
+}
+
+// Re-anchors pacing to the last released packet and wakes up waiting packet.
+// Lock must be held.
+func (r *Replay) notify() {
+	if !r.last.IsZero() {
+		r.anchorCapture, r.anchorWall = r.last, time.Now()
+	}
+
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
@@ -0,0 +1,259 @@
+package wifi
+
+import (
+	"strings"
+	"wfmon/pkg/utils/cmp"
+)
+
+// https://mrncciew.com/2014/08/21/cwap-802-11-security-rsn-information-element/
+// https://mrncciew.com/2014/08/19/cwap-802-11-security-wpa-wpa2/
+
+// Suite selectors organizationally unique identifiers.
+const (
+	rsnOUI = 0x000fac // IEEE 802.11 suites
+	wpaOUI = 0x0050f2 // Microsoft WPA suites
+)
+
+// WPA vendor specific element type.
+const wpaOUIType = 0x01
+
+// Cipher suite type of RSN and WPA elements.
+type CipherSuite uint8
+
+const (
+	CipherUseGroup        CipherSuite = 0   // use group cipher suite
+	CipherWEP40           CipherSuite = 1   // WEP-40
+	CipherTKIP            CipherSuite = 2   // TKIP
+	CipherCCMP            CipherSuite = 4   // CCMP-128
+	CipherWEP104          CipherSuite = 5   // WEP-104
+	CipherBIPCMAC128      CipherSuite = 6   // BIP-CMAC-128
+	CipherGroupNotAllowed CipherSuite = 7   // group addressed traffic not allowed
+	CipherGCMP128         CipherSuite = 8   // GCMP-128
+	CipherGCMP256         CipherSuite = 9   // GCMP-256
+	CipherCCMP256         CipherSuite = 10  // CCMP-256
+	CipherBIPGMAC128      CipherSuite = 11  // BIP-GMAC-128
+	CipherBIPGMAC256      CipherSuite = 12  // BIP-GMAC-256
+	CipherBIPCMAC256      CipherSuite = 13  // BIP-CMAC-256
+	CipherVendor          CipherSuite = 255 // vendor specific suite
+)
+
+func (c CipherSuite) String() string {
+	//nolint:exhaustive // ignore
+	switch c {
+	case CipherUseGroup:
+		return "GROUP"
+	case CipherWEP40:
+		return "WEP-40"
+	case CipherTKIP:
+		return "TKIP"
+	case CipherCCMP:
+		return "CCMP"
+	case CipherWEP104:
+		return "WEP-104"
+	case CipherBIPCMAC128:
+		return "BIP-CMAC-128"
+	case CipherGroupNotAllowed:
+		return "NO-GROUP"
+	case CipherGCMP128:
+		return "GCMP"
+	case CipherGCMP256:
+		return "GCMP-256"
+	case CipherCCMP256:
+		return "CCMP-256"
+	case CipherBIPGMAC128:
+		return "BIP-GMAC-128"
+	case CipherBIPGMAC256:
+		return "BIP-GMAC-256"
+	case CipherBIPCMAC256:
+		return "BIP-CMAC-256"
+	default:
+		return "VENDOR"
+	}
+}
+
+// Returns true for deprecated ciphers: WEP and TKIP.
+func (c CipherSuite) Weak() bool {
+	return c == CipherWEP40 || c == CipherWEP104 || c == CipherTKIP
+}
+
+// Returns the weaker of two ciphers: WEP-40 is weaker than WEP-104, which is weaker than TKIP.
+// Ciphers that are not deprecated are ignored, so the result is not weak if both are not.
+func weakerCipher(a, b CipherSuite) CipherSuite {
+	weakness := func(c CipherSuite) int {
+		//nolint:exhaustive // ignore
+		switch c {
+		case CipherWEP40:
+			return 3 //nolint:gomnd // ignore
+		case CipherWEP104:
+			return 2 //nolint:gomnd // ignore
+		case CipherTKIP:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return cmp.Nvl(weakness(b) > weakness(a), b, a)
+}
+
+// Authentication and Key Management suite type of RSN and WPA elements.
+type AKMSuite uint8
+
+const (
+	AKM8021X          AKMSuite = 1   // 802.1X (EAP)
+	AKMPSK            AKMSuite = 2   // Pre-Shared Key
+	AKMFT8021X        AKMSuite = 3   // Fast Transition over 802.1X
+	AKMFTPSK          AKMSuite = 4   // Fast Transition over PSK
+	AKM8021XSHA256    AKMSuite = 5   // 802.1X with SHA-256
+	AKMPSKSHA256      AKMSuite = 6   // PSK with SHA-256
+	AKMTDLS           AKMSuite = 7   // Tunneled Direct Link Setup
+	AKMSAE            AKMSuite = 8   // Simultaneous Authentication of Equals
+	AKMFTSAE          AKMSuite = 9   // Fast Transition over SAE
+	AKMAPPeerKey      AKMSuite = 10  // AP Peer Key
+	AKM8021XSuiteB    AKMSuite = 11  // 802.1X Suite B
+	AKM8021XSuiteB192 AKMSuite = 12  // 802.1X Suite B 192-bit
+	AKMFT8021XSHA384  AKMSuite = 13  // Fast Transition over 802.1X with SHA-384
+	AKMOWE            AKMSuite = 18  // Opportunistic Wireless Encryption
+	AKMSAEExtKey      AKMSuite = 24  // SAE with group-dependent hash
+	AKMFTSAEExtKey    AKMSuite = 25  // Fast Transition over SAE with group-dependent hash
+	AKMVendor         AKMSuite = 255 // vendor specific suite
+)
+
+func (a AKMSuite) String() string {
+	//nolint:exhaustive // ignore
+	switch a {
+	case AKM8021X:
+		return "802.1X"
+	case AKMPSK:
+		return "PSK"
+	case AKMFT8021X:
+		return "FT-802.1X"
+	case AKMFTPSK:
+		return "FT-PSK"
+	case AKM8021XSHA256:
+		return "802.1X-SHA256"
+	case AKMPSKSHA256:
+		return "PSK-SHA256"
+	case AKMTDLS:
+		return "TDLS"
+	case AKMSAE:
+		return "SAE"
+	case AKMFTSAE:
+		return "FT-SAE"
+	case AKMAPPeerKey:
+		return "APPeerKey"
+	case AKM8021XSuiteB:
+		return "802.1X-SuiteB"
+	case AKM8021XSuiteB192:
+		return "802.1X-SuiteB-192"
+	case AKMFT8021XSHA384:
+		return "FT-802.1X-SHA384"
+	case AKMOWE:
+		return "OWE"
+	case AKMSAEExtKey:
+		return "SAE-EXT-KEY"
+	case AKMFTSAEExtKey:
+		return "FT-SAE-EXT-KEY"
+	default:
+		return "VENDOR"
+	}
+}
+
+// Returns authentication method of AKM suite.
+func (a AKMSuite) Auth() AuthMethod {
+	//nolint:exhaustive // ignore
+	switch a {
+	case AKMPSK, AKMFTPSK, AKMPSKSHA256:
+		return AuthPSK
+	case AKMSAE, AKMFTSAE, AKMSAEExtKey, AKMFTSAEExtKey:
+		return AuthSAE
+	case AKM8021X, AKMFT8021X, AKM8021XSHA256, AKM8021XSuiteB, AKM8021XSuiteB192, AKMFT8021XSHA384:
+		return Auth8021X
+	case AKMOWE:
+		return AuthOWE
+	default:
+		return AuthNone
+	}
+}
+
+// Authentication methods bitmask.
+type AuthMethod uint8
+
+const (
+	AuthNone  AuthMethod = 0
+	AuthPSK   AuthMethod = 1 << 0 // Personal, Pre-Shared Key
+	AuthSAE   AuthMethod = 1 << 1 // Personal, Simultaneous Authentication of Equals
+	Auth8021X AuthMethod = 1 << 2 // Enterprise, EAP
+	AuthOWE   AuthMethod = 1 << 3 // Opportunistic Wireless Encryption
+)
+
+func (a AuthMethod) String() string {
+	names := []string{}
+	for _, m := range []struct {
+		flag AuthMethod
+		name string
+	}{
+		{AuthPSK, "PSK"},
+		{AuthSAE, "SAE"},
+		{Auth8021X, "EAP"},
+		{AuthOWE, "OWE"},
+	} {
+		if a&m.flag != 0 {
+			names = append(names, m.name)
+		}
+	}
+
+	return strings.Join(names, "/")
+}
+
+// Returns true if the element was advertised.
+func (ie *RSNIE) Present() bool {
+	return ie.Version > 0
+}
+
+// Returns authentication methods of all advertised AKM suites.
+func (ie *RSNIE) Auth() AuthMethod {
+	var auth AuthMethod
+	for _, akm := range ie.AKMSuites {
+		auth |= akm.Auth()
+	}
+
+	return auth
+}
+
+// Returns true if group or any of pairwise ciphers is deprecated.
+func (ie *RSNIE) Weak() bool {
+	return ie.WeakCipher().Weak()
+}
+
+// Returns the weakest of group and pairwise ciphers, it is not weak if none of them is deprecated.
+func (ie *RSNIE) WeakCipher() CipherSuite {
+	weakest := ie.GroupCipher
+	for _, cipher := range ie.PairwiseCiphers {
+		weakest = weakerCipher(weakest, cipher)
+	}
+
+	return weakest
+}
+
+// Returns security protocols advertised by RSN element.
+// WPA3 networks use SAE, Suite B 192-bit or OWE, others are WPA2.
+func (ie *RSNIE) Protocols() SecurityProtocol {
+	var protocols SecurityProtocol
+	for _, akm := range ie.AKMSuites {
+		switch {
+		case akm.Auth() == AuthSAE || akm == AKM8021XSuiteB192:
+			protocols |= SecurityWPA3
+		case akm.Auth() == AuthOWE:
+			protocols |= SecurityOWE
+		default:
+			protocols |= SecurityWPA2
+		}
+	}
+
+	if protocols == SecurityOpen {
+		protocols = SecurityWPA2
+	}
+
+	return protocols
+}
@@ -0,0 +1,103 @@
+package wifi
+
+import (
+	"reflect"
+	"testing"
+)
+
+// RSN element body of WPA2-PSK with CCMP.
+var rsnWPA2PSK = []byte{
+	0x01, 0x00, // version
+	0x00, 0x0f, 0xac, 0x04, // group cipher CCMP
+	0x01, 0x00, 0x00, 0x0f, 0xac, 0x04, // pairwise ciphers: CCMP
+	0x01, 0x00, 0x00, 0x0f, 0xac, 0x02, // AKM suites: PSK
+	0x80, 0x00, // capabilities: MFP capable
+}
+
+func TestDecodeRSN(t *testing.T) {
+	tests := []struct {
+		name string
+		info []byte
+		oui  uint32
+		want RSNIE
+		ok   bool
+	}{
+		{"empty", nil, rsnOUI, RSNIE{}, false},
+		{"truncated version", []byte{0x01}, rsnOUI, RSNIE{}, false},
+		{"version only keeps RSN defaults", []byte{0x01, 0x00}, rsnOUI,
+			RSNIE{Version: 1, GroupCipher: CipherCCMP, PairwiseCiphers: []CipherSuite{CipherCCMP}, AKMSuites: []AKMSuite{AKM8021X}}, true},
+		{"version only keeps WPA defaults", []byte{0x01, 0x00}, wpaOUI,
+			RSNIE{Version: 1, GroupCipher: CipherTKIP, PairwiseCiphers: []CipherSuite{CipherTKIP}, AKMSuites: []AKMSuite{AKM8021X}}, true},
+		{"complete", rsnWPA2PSK, rsnOUI,
+			RSNIE{Version: 1, GroupCipher: CipherCCMP, PairwiseCiphers: []CipherSuite{CipherCCMP}, AKMSuites: []AKMSuite{AKMPSK}, MFPCapable: true}, true},
+		{"truncated group cipher", rsnWPA2PSK[:5], rsnOUI,
+			RSNIE{Version: 1, GroupCipher: CipherCCMP, PairwiseCiphers: []CipherSuite{CipherCCMP}, AKMSuites: []AKMSuite{AKM8021X}}, true},
+		{"truncated capabilities", rsnWPA2PSK[:19], rsnOUI,
+			RSNIE{Version: 1, GroupCipher: CipherCCMP, PairwiseCiphers: []CipherSuite{CipherCCMP}, AKMSuites: []AKMSuite{AKMPSK}}, true},
+		{"pairwise count exceeds suites present",
+			[]byte{0x01, 0x00, 0x00, 0x0f, 0xac, 0x02, 0xff, 0xff, 0x00, 0x0f, 0xac, 0x02}, rsnOUI,
+			RSNIE{Version: 1, GroupCipher: CipherTKIP, PairwiseCiphers: []CipherSuite{CipherTKIP}, AKMSuites: []AKMSuite{AKM8021X}}, true},
+		{"zero pairwise ciphers",
+			[]byte{0x01, 0x00, 0x00, 0x0f, 0xac, 0x04, 0x00, 0x00}, rsnOUI,
+			RSNIE{Version: 1, GroupCipher: CipherCCMP, PairwiseCiphers: []CipherSuite{}, AKMSuites: []AKMSuite{AKM8021X}}, true},
+		{"suites of another OUI are vendor specific",
+			[]byte{0x01, 0x00, 0x00, 0x50, 0xf2, 0x02, 0x01, 0x00, 0x00, 0x0f, 0xac, 0x04, 0x01, 0x00, 0x00, 0x50, 0xf2, 0x02}, rsnOUI,
+			RSNIE{Version: 1, GroupCipher: CipherVendor, PairwiseCiphers: []CipherSuite{CipherCCMP}, AKMSuites: []AKMSuite{AKMVendor}}, true},
+		{"WPA suites",
+			[]byte{0x01, 0x00, 0x00, 0x50, 0xf2, 0x02, 0x01, 0x00, 0x00, 0x50, 0xf2, 0x02, 0x01, 0x00, 0x00, 0x50, 0xf2, 0x02}, wpaOUI,
+			RSNIE{Version: 1, GroupCipher: CipherTKIP, PairwiseCiphers: []CipherSuite{CipherTKIP}, AKMSuites: []AKMSuite{AKMPSK}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := decodeRSN(tt.info, tt.oui)
+			if ok != tt.ok {
+				t.Fatalf("ok = %t, want %t", ok, tt.ok)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSecurityString(t *testing.T) {
+	rsn := func(group CipherSuite, pairwise CipherSuite, akms ...AKMSuite) RSNIE {
+		return RSNIE{Version: 1, GroupCipher: group, PairwiseCiphers: []CipherSuite{pairwise}, AKMSuites: akms}
+	}
+
+	tests := []struct {
+		name     string
+		privacy  bool
+		ie       SecurityIE
+		want     string
+		insecure bool
+	}{
+		{"open", false, SecurityIE{}, "Open", true},
+		{"pre-RSNA WEP has no cipher suffix", true, SecurityIE{}, "WEP", true},
+		{"WPA2", true, SecurityIE{RSN: rsn(CipherCCMP, CipherCCMP, AKMPSK)}, "WPA2-PSK", false},
+		{"WPA2/WPA3 transition", true, SecurityIE{RSN: rsn(CipherCCMP, CipherCCMP, AKMPSK, AKMSAE)}, "WPA2/WPA3-PSK/SAE", false},
+		{"TKIP group cipher", true, SecurityIE{RSN: rsn(CipherTKIP, CipherCCMP, AKMPSK)}, "WPA2-PSK TKIP", true},
+		{"WEP-40 group cipher", true, SecurityIE{RSN: rsn(CipherWEP40, CipherCCMP, AKMPSK)}, "WPA2-PSK WEP-40", true},
+		{"WEP-104 group cipher", true, SecurityIE{RSN: rsn(CipherWEP104, CipherCCMP, AKMPSK)}, "WPA2-PSK WEP-104", true},
+		{"weakest of WPA and RSN", true,
+			SecurityIE{WPA: rsn(CipherTKIP, CipherTKIP, AKMPSK), RSN: rsn(CipherWEP104, CipherCCMP, AKMPSK)}, "WPA/WPA2-PSK WEP-104", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			capabilities := CapabilityInfo(0)
+			if tt.privacy {
+				capabilities = capabilityPrivacy
+			}
+
+			sec := GetSecurity(capabilities, tt.ie)
+			if got := sec.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if got := sec.Insecure(); got != tt.insecure {
+				t.Errorf("insecure = %t, want %t", got, tt.insecure)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package wifi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Capability Information field of Beacon, Probe Response and (Re)Association Response frames.
+// https://mrncciew.com/2014/10/08/802-11-mgmt-beacon-frame/
+type CapabilityInfo uint16
+
+const (
+	capabilityESS     CapabilityInfo = 1 << 0
+	capabilityIBSS    CapabilityInfo = 1 << 1
+	capabilityPrivacy CapabilityInfo = 1 << 4
+)
+
+// Returns true if BSS is an infrastructure network.
+func (c CapabilityInfo) ESS() bool {
+	return c&capabilityESS != 0
+}
+
+// Returns true if BSS is an ad-hoc network.
+func (c CapabilityInfo) IBSS() bool {
+	return c&capabilityIBSS != 0
+}
+
+// Returns true if data confidentiality (WEP or higher) is required.
+func (c CapabilityInfo) Privacy() bool {
+	return c&capabilityPrivacy != 0
+}
+
+// Returns capabilities presentation, e.g. ESS/Privacy.
+func (c CapabilityInfo) String() string {
+	names := []string{}
+	if c.ESS() {
+		names = append(names, "ESS")
+	}
+	if c.IBSS() {
+		names = append(names, "IBSS")
+	}
+	if c.Privacy() {
+		names = append(names, "Privacy")
+	}
+
+	return strings.Join(names, "/")
+}
+
+// Security protocols bitmask.
+// Higher bit means stronger protocol, so the value can be used for sorting.
+type SecurityProtocol uint8
+
+const (
+	SecurityOpen SecurityProtocol = 0
+	SecurityWEP  SecurityProtocol = 1 << 0
+	SecurityWPA  SecurityProtocol = 1 << 1
+	SecurityOWE  SecurityProtocol = 1 << 2
+	SecurityWPA2 SecurityProtocol = 1 << 3
+	SecurityWPA3 SecurityProtocol = 1 << 4
+)
+
+func (p SecurityProtocol) String() string {
+	if p == SecurityOpen {
+		return "Open"
+	}
+
+	names := []string{}
+	for _, s := range []struct {
+		flag SecurityProtocol
+		name string
+	}{
+		{SecurityWEP, "WEP"},
+		{SecurityWPA, "WPA"},
+		{SecurityOWE, "OWE"},
+		{SecurityWPA2, "WPA2"},
+		{SecurityWPA3, "WPA3"},
+	} {
+		if p&s.flag != 0 {
+			names = append(names, s.name)
+		}
+	}
+
+	return strings.Join(names, "/")
+}
+
+// Security summary of a BSS.
+type Security struct {
+	Protocols SecurityProtocol // Advertised security protocols
+	Auth      AuthMethod       // Advertised authentication methods
+	Weak      bool             // WEP or TKIP cipher is in use
+	Cipher    CipherSuite      // Weakest cipher advertised by WPA and RSN elements
+}
+
+// Determines security summary from capability Privacy bit and security elements.
+func GetSecurity(capabilities CapabilityInfo, ie SecurityIE) Security {
+	var sec Security
+
+	if ie.WPA.Present() {
+		sec.Protocols |= SecurityWPA
+		sec.Auth |= ie.WPA.Auth()
+		sec.Weak = sec.Weak || ie.WPA.Weak()
+		sec.Cipher = weakerCipher(sec.Cipher, ie.WPA.WeakCipher())
+	}
+
+	if ie.RSN.Present() {
+		sec.Protocols |= ie.RSN.Protocols()
+		sec.Auth |= ie.RSN.Auth()
+		sec.Weak = sec.Weak || ie.RSN.Weak()
+		sec.Cipher = weakerCipher(sec.Cipher, ie.RSN.WeakCipher())
+	}
+
+	// pre-RSNA security
+	if sec.Protocols == SecurityOpen && capabilities.Privacy() {
+		sec.Protocols = SecurityWEP
+		sec.Weak = true
+	}
+
+	return sec
+}
+
+// Returns true for networks without encryption or with deprecated WEP/TKIP encryption.
+func (s Security) Insecure() bool {
+	return s.Protocols == SecurityOpen || s.Weak
+}
+
+// Returns string presentation, e.g. Open, WEP, WPA2-PSK, WPA2/WPA3-PSK/SAE, WPA/WPA2-PSK TKIP, WPA2-PSK WEP-104.
+// Weak suffix names the weakest advertised cipher, pre-RSNA WEP networks have none.
+func (s Security) String() string {
+	text := s.Protocols.String()
+
+	if auth := s.Auth.String(); len(auth) > 0 && s.Auth != AuthOWE {
+		text = fmt.Sprintf("%s-%s", text, auth)
+	}
+
+	if s.Cipher.Weak() && s.Protocols&SecurityWEP == 0 {
+		text = fmt.Sprintf("%s %s", text, s.Cipher)
+	}
+
+	return text
+}
@@ -0,0 +1,71 @@
+package wifi
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// Network name decoded from SSID element: printable text with non-printable octets escaped as \xNN
+// and backslash escaped as \\, so escaped octets are distinguishable from literal text.
+// SSID element is up to 32 octets of unspecified encoding, unless Extended Capabilities declare UTF-8.
+type SSID string
+
+// Returns SSID decoded from raw element octets.
+// Octets are decoded as UTF-8 if it is declared or valid, invalid and non-printable sequences are escaped.
+// Otherwise encoding is unknown, e.g. legacy code page, so every non-ASCII octet is escaped.
+func DecodeSSID(raw []byte, utf8Declared bool) SSID {
+	var sb strings.Builder
+	escape := func(octets []byte) {
+		for _, b := range octets {
+			fmt.Fprintf(&sb, `\x%02x`, b)
+		}
+	}
+
+	decodeUTF8 := utf8Declared || utf8.Valid(raw)
+	for len(raw) > 0 {
+		r, size := utf8.DecodeRune(raw)
+		switch {
+		case r == '\\':
+			sb.WriteString(`\\`)
+		case !decodeUTF8 && r >= utf8.RuneSelf,
+			r == utf8.RuneError && size <= 1,
+			!unicode.IsPrint(r):
+			escape(raw[:1])
+			size = 1
+		default:
+			sb.WriteRune(r)
+		}
+		raw = raw[size:]
+	}
+
+	return SSID(sb.String())
+}
+
+// Returns true if SSID element hides network name: zero length or filled with NUL bytes.
+func HiddenSSID(raw []byte) bool {
+	for _, b := range raw {
+		if b != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns width of SSID in terminal cells, wide runes like CJK and emoji take two cells.
+func (s SSID) Width() int {
+	return runewidth.StringWidth(string(s))
+}
+
+// Returns SSID truncated to fit width in terminal cells, truncated SSID ends with ellipsis.
+func (s SSID) Truncate(width int) string {
+	return runewidth.Truncate(string(s), width, "…")
+}
+
+func (s SSID) String() string {
+	return string(s)
+}
@@ -0,0 +1,81 @@
+package wifi
+
+import "testing"
+
+func TestDecodeSSID(t *testing.T) {
+	tests := []struct {
+		name         string
+		raw          []byte
+		utf8Declared bool
+		want         SSID
+	}{
+		{"empty", nil, false, ""},
+		{"ASCII", []byte("Office"), false, "Office"},
+		{"backslash", []byte(`a\b`), false, `a\\b`},
+		{"literal escape sequence", []byte(`\x41`), false, `\\x41`},
+		{"valid UTF-8", []byte("Café"), false, "Café"},
+		{"wide runes", []byte("東京 📶"), false, "東京 📶"},
+		{"control characters", []byte("a\x00b\x1b"), false, `a\x00b\x1b`},
+		{"invalid UTF-8 escapes non-ASCII octets", []byte{'C', 'a', 'f', 0xe9}, false, `Caf\xe9`},
+		{"invalid UTF-8 keeps valid runes undecoded", []byte{0xc3, 0xa9, 0xff}, false, `\xc3\xa9\xff`},
+		{"declared UTF-8 escapes invalid sequences only", []byte{0xc3, 0xa9, 0xff}, true, `é\xff`},
+		{"declared UTF-8 truncated rune", []byte{'a', 0xe6, 0x9d}, true, `a\xe6\x9d`},
+		{"non-printable rune", []byte("a\u200bb"), false, `a\xe2\x80\x8bb`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DecodeSSID(tt.raw, tt.utf8Declared); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHiddenSSID(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  []byte
+		want bool
+	}{
+		{"empty", nil, true},
+		{"NUL filled", []byte{0, 0, 0, 0}, true},
+		{"name", []byte("Office"), false},
+		{"NUL inside name", []byte{'a', 0}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HiddenSSID(tt.raw); got != tt.want {
+				t.Errorf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSSIDWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		ssid     SSID
+		width    int
+		truncate int
+		want     string
+	}{
+		{"ASCII fits", "Office", 6, 6, "Office"},
+		{"ASCII truncated", "Office", 6, 4, "Off…"},
+		{"wide runes take two cells", "東京", 4, 4, "東京"},
+		{"wide runes truncated", "東京タワー", 10, 5, "東京…"},
+		{"emoji", "📶 wifi", 7, 3, "📶…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ssid.Width(); got != tt.width {
+				t.Errorf("width %d, want %d", got, tt.width)
+			}
+			if got := tt.ssid.Truncate(tt.truncate); got != tt.want {
+				t.Errorf("truncated %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
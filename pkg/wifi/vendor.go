@@ -0,0 +1,201 @@
+package wifi
+
+import (
+	"encoding/binary"
+	"strings"
+	"wfmon/pkg/utils/cmp"
+)
+
+// Wi-Fi Simple Configuration Technical Specification, Data Element Definitions.
+// Wi-Fi Peer-to-Peer (P2P) Technical Specification, P2P IE format.
+
+// Vendor specific elements organizationally unique identifiers.
+const wfaOUI = 0x506f9a // Wi-Fi Alliance
+
+// Vendor specific element types.
+const (
+	wpsOUIType = 0x04 // Wi-Fi Protected Setup within Microsoft OUI
+	p2pOUIType = 0x09 // Wi-Fi Direct within Wi-Fi Alliance OUI
+)
+
+// Key of vendor specific element decoder: OUI and vendor specific type.
+type vendorKey struct {
+	oui     uint32
+	ouiType uint8
+}
+
+// Decodes vendor specific element body without OUI and type.
+type vendorDecoder func(ie *InformationElements, info []byte)
+
+// Registered decoders of vendor specific elements, looked up for every vendor element of every frame.
+var vendorDecoders = map[vendorKey]vendorDecoder{
+	{oui: wpaOUI, ouiType: wpaOUIType}: (*InformationElements).discoverWPAIE,
+	{oui: wpaOUI, ouiType: wpsOUIType}: (*InformationElements).discoverWPSIE,
+	{oui: wfaOUI, ouiType: p2pOUIType}: (*InformationElements).discoverP2PIE,
+}
+
+// Discovers Microsoft WPA element, it has RSN layout within Microsoft suites.
+func (ie *InformationElements) discoverWPAIE(info []byte) {
+	if wpa, ok := decodeRSN(info, wpaOUI); ok {
+		ie.WPA = wpa
+	}
+}
+
+// Wi-Fi Protected Setup attribute types.
+const (
+	wpsAttrConfigMethods = 0x1008
+	wpsAttrDeviceName    = 0x1011
+	wpsAttrManufacturer  = 0x1021
+	wpsAttrModelName     = 0x1023
+	wpsAttrModelNumber   = 0x1024
+	wpsAttrSerialNumber  = 0x1042
+	wpsAttrState         = 0x1044
+	wpsAttrAPSetupLocked = 0x1057
+)
+
+// Discovers Wi-Fi Protected Setup element.
+// Layout: attributes of type(2) length(2) value(length), big endian.
+// Long element might be fragmented into several elements: attributes of all elements are decoded together,
+// so an attribute cut by fragmentation is decoded once the next element completes it.
+func (ie *InformationElements) discoverWPSIE(fragment []byte) {
+	const headerLen = 4
+
+	ie.WPSValid = true
+	ie.wpsAttrs = append(ie.wpsAttrs, fragment...)
+	info := ie.wpsAttrs
+	for len(info) >= headerLen {
+		attr, length := binary.BigEndian.Uint16(info[0:2]), int(binary.BigEndian.Uint16(info[2:4]))
+		// malformed attribute or fragmented one continuing in the next element
+		if length > len(info)-headerLen {
+			return
+		}
+
+		value := info[headerLen : headerLen+length]
+		switch attr {
+		case wpsAttrState:
+			if len(value) > 0 {
+				ie.WPSState = WPSState(value[0])
+			}
+		case wpsAttrAPSetupLocked:
+			ie.APSetupLocked = len(value) > 0 && value[0] != 0
+		case wpsAttrDeviceName:
+			ie.DeviceName = DecodeSSID(value, true).String()
+		case wpsAttrManufacturer:
+			ie.Manufacturer = DecodeSSID(value, true).String()
+		case wpsAttrModelName:
+			ie.ModelName = DecodeSSID(value, true).String()
+		case wpsAttrModelNumber:
+			ie.ModelNumber = DecodeSSID(value, true).String()
+		case wpsAttrSerialNumber:
+			ie.SerialNumber = DecodeSSID(value, true).String()
+		case wpsAttrConfigMethods:
+			if len(value) >= 2 { //nolint:gomnd // ignore
+				ie.ConfigMethods = WPSConfigMethods(binary.BigEndian.Uint16(value))
+			}
+		}
+		info = info[headerLen+length:]
+	}
+}
+
+// Discovers Wi-Fi Direct element.
+// Layout: attributes of id(1) length(2) value(length), little endian.
+// P2P Capability attribute: device capability(1) group capability(1).
+func (ie *InformationElements) discoverP2PIE(info []byte) {
+	const (
+		headerLen          = 3
+		attrCapability     = 2
+		capabilityLen      = 2
+		groupOwnerBit      = 0x01
+		groupCapabilityIdx = 1
+	)
+
+	ie.P2P = true
+	for len(info) >= headerLen {
+		attr, length := info[0], int(binary.LittleEndian.Uint16(info[1:3]))
+		// malformed attribute
+		if length > len(info)-headerLen {
+			return
+		}
+
+		value := info[headerLen : headerLen+length]
+		if attr == attrCapability && len(value) >= capabilityLen {
+			ie.P2PGroupOwner = value[groupCapabilityIdx]&groupOwnerBit != 0
+		}
+		info = info[headerLen+length:]
+	}
+}
+
+// Wi-Fi Protected Setup state.
+type WPSState uint8
+
+const (
+	WPSNotConfigured WPSState = 1
+	WPSConfigured    WPSState = 2
+)
+
+func (s WPSState) String() string {
+	//nolint:exhaustive // ignore
+	switch s {
+	case WPSNotConfigured:
+		return "not configured"
+	case WPSConfigured:
+		return "configured"
+	default:
+		return "unknown"
+	}
+}
+
+// Wi-Fi Protected Setup configuration methods bit field.
+type WPSConfigMethods uint16
+
+// Returns names of configuration methods, e.g. "Display, PushButton, Keypad".
+// Virtual and physical variants of methods are not distinguished.
+func (m WPSConfigMethods) String() string {
+	//nolint:gomnd // ignore
+	methods := []struct {
+		bit  WPSConfigMethods
+		name string
+	}{
+		{0x0001, "USB"},
+		{0x0002, "Ethernet"},
+		{0x0004, "Label"},
+		{0x0008, "Display"},
+		{0x0010, "External NFC"},
+		{0x0020, "Integrated NFC"},
+		{0x0040, "NFC Interface"},
+		{0x0080, "PushButton"},
+		{0x0100, "Keypad"},
+	}
+
+	names := []string{}
+	for _, method := range methods {
+		if m&method.bit != 0 {
+			names = append(names, method.name)
+		}
+	}
+
+	return cmp.Nvl(len(names) > 0, strings.Join(names, ", "), "none")
+}
+
+// Returns model name and number of device, e.g. "Archer C7 5.0".
+func (ie *WPSIE) Model() string {
+	return strings.TrimSpace(ie.ModelName + " " + ie.ModelNumber)
+}
+
+// Returns WPS element with device attributes missing in it taken from previous one.
+// Beacons carry state only, while probe responses describe device as well.
+func (ie *WPSIE) Merge(prev WPSIE) WPSIE {
+	if !ie.WPSValid {
+		return prev
+	}
+
+	merged := *ie
+	merged.DeviceName = cmp.Nvl(len(ie.DeviceName) > 0, ie.DeviceName, prev.DeviceName)
+	merged.Manufacturer = cmp.Nvl(len(ie.Manufacturer) > 0, ie.Manufacturer, prev.Manufacturer)
+	merged.ModelName = cmp.Nvl(len(ie.ModelName) > 0, ie.ModelName, prev.ModelName)
+	merged.ModelNumber = cmp.Nvl(len(ie.ModelNumber) > 0, ie.ModelNumber, prev.ModelNumber)
+	merged.SerialNumber = cmp.Nvl(len(ie.SerialNumber) > 0, ie.SerialNumber, prev.SerialNumber)
+	merged.ConfigMethods = cmp.Nvl(ie.ConfigMethods != 0, ie.ConfigMethods, prev.ConfigMethods)
+
+	return merged
+}
@@ -0,0 +1,153 @@
+package wifi
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/google/gopacket/layers"
+)
+
+// Returns WPS attribute: type(2) length(2) value, big endian.
+func wpsAttr(attr uint16, value ...byte) []byte {
+	b := binary.BigEndian.AppendUint16(nil, attr)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(value)))
+	return append(b, value...)
+}
+
+func concat(parts ...[]byte) []byte {
+	var b []byte
+	for _, p := range parts {
+		b = append(b, p...)
+	}
+	return b
+}
+
+func TestDiscoverWPSIE(t *testing.T) {
+	device := concat(
+		wpsAttr(wpsAttrState, byte(WPSConfigured)),
+		wpsAttr(wpsAttrAPSetupLocked, 1),
+		wpsAttr(wpsAttrDeviceName, []byte("Printer")...),
+		wpsAttr(wpsAttrManufacturer, []byte("HP")...),
+		wpsAttr(wpsAttrModelName, []byte("LaserJet")...),
+		wpsAttr(wpsAttrModelNumber, []byte("M15")...),
+		wpsAttr(wpsAttrSerialNumber, []byte("CN123")...),
+		wpsAttr(wpsAttrConfigMethods, 0x01, 0x80),
+	)
+	complete := WPSIE{WPSValid: true, WPSState: WPSConfigured, APSetupLocked: true, DeviceName: "Printer", Manufacturer: "HP",
+		ModelName: "LaserJet", ModelNumber: "M15", SerialNumber: "CN123", ConfigMethods: 0x0180}
+
+	tests := []struct {
+		name      string
+		fragments [][]byte
+		want      WPSIE
+	}{
+		{"empty", [][]byte{nil}, WPSIE{WPSValid: true}},
+		{"state only", [][]byte{wpsAttr(wpsAttrState, byte(WPSNotConfigured))}, WPSIE{WPSValid: true, WPSState: WPSNotConfigured}},
+		{"device attributes", [][]byte{device}, complete},
+		{"truncated header", [][]byte{append(wpsAttr(wpsAttrState, 2), 0x10, 0x11, 0x00)},
+			WPSIE{WPSValid: true, WPSState: WPSConfigured}},
+		{"length exceeds element", [][]byte{append(wpsAttr(wpsAttrState, 2), 0x10, 0x11, 0x00, 0x20, 'a')},
+			WPSIE{WPSValid: true, WPSState: WPSConfigured}},
+		{"empty values are ignored", [][]byte{concat(wpsAttr(wpsAttrState), wpsAttr(wpsAttrAPSetupLocked), wpsAttr(wpsAttrConfigMethods, 0x01))},
+			WPSIE{WPSValid: true}},
+		{"attribute cut by fragmentation", [][]byte{device[:20], device[20:]}, complete},
+		{"header cut by fragmentation", [][]byte{device[:7], device[7:]}, complete},
+		{"unknown attributes are skipped", [][]byte{concat(wpsAttr(0x1049, 0x00, 0x37, 0x2a), wpsAttr(wpsAttrDeviceName, 'A', 'P'))},
+			WPSIE{WPSValid: true, DeviceName: "AP"}},
+		{"device name of unknown encoding is escaped", [][]byte{wpsAttr(wpsAttrDeviceName, 'A', 0xff, '\\')},
+			WPSIE{WPSValid: true, DeviceName: `A\xff\\`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ie InformationElements
+			for _, fragment := range tt.fragments {
+				ie.discoverWPSIE(fragment)
+			}
+			if ie.WPSIE != tt.want {
+				t.Errorf("got %+v, want %+v", ie.WPSIE, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscoverP2PIE(t *testing.T) {
+	tests := []struct {
+		name string
+		info []byte
+		want P2PIE
+	}{
+		{"empty", nil, P2PIE{P2P: true}},
+		{"client", []byte{0x02, 0x02, 0x00, 0x25, 0x00}, P2PIE{P2P: true}},
+		{"group owner", []byte{0x02, 0x02, 0x00, 0x25, 0x01}, P2PIE{P2P: true, P2PGroupOwner: true}},
+		{"capability after other attribute", []byte{0x0d, 0x01, 0x00, 0xaa, 0x02, 0x02, 0x00, 0x25, 0x01},
+			P2PIE{P2P: true, P2PGroupOwner: true}},
+		{"truncated capability", []byte{0x02, 0x01, 0x00, 0x25}, P2PIE{P2P: true}},
+		{"length exceeds element", []byte{0x02, 0x05, 0x00, 0x25, 0x01}, P2PIE{P2P: true}},
+		{"truncated header", []byte{0x02, 0x02}, P2PIE{P2P: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ie InformationElements
+			ie.discoverP2PIE(tt.info)
+			if ie.P2PIE != tt.want {
+				t.Errorf("got %+v, want %+v", ie.P2PIE, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscoverVendorIE(t *testing.T) {
+	tests := []struct {
+		name string
+		oui  []byte
+		info []byte
+		wps  bool
+		p2p  bool
+		wpa  bool
+	}{
+		{"WPS", []byte{0x00, 0x50, 0xf2, 0x04}, wpsAttr(wpsAttrState, 2), true, false, false},
+		{"WPA", []byte{0x00, 0x50, 0xf2, 0x01}, []byte{0x01, 0x00}, false, false, true},
+		{"P2P", []byte{0x50, 0x6f, 0x9a, 0x09}, nil, false, true, false},
+		{"unknown type", []byte{0x00, 0x50, 0xf2, 0x02}, []byte{0x01, 0x00}, false, false, false},
+		{"unknown OUI", []byte{0x00, 0x17, 0xf2, 0x06}, []byte{0x01, 0x00}, false, false, false},
+		{"truncated OUI and type", []byte{0x00, 0x50, 0xf2}, []byte{0x04}, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ie InformationElements
+			ie.discoverVendorIE(&layers.Dot11InformationElement{ID: layers.Dot11InformationElementIDVendor, OUI: tt.oui, Info: tt.info})
+			if ie.WPSValid != tt.wps || ie.P2P != tt.p2p || ie.WPA.Present() != tt.wpa {
+				t.Errorf("WPS %t, P2P %t, WPA %t, want %t, %t, %t", ie.WPSValid, ie.P2P, ie.WPA.Present(), tt.wps, tt.p2p, tt.wpa)
+			}
+		})
+	}
+}
+
+func TestWPSMerge(t *testing.T) {
+	probe := WPSIE{WPSValid: true, WPSState: WPSNotConfigured, DeviceName: "Printer", ModelName: "LaserJet", ConfigMethods: 0x0080}
+	beacon := WPSIE{WPSValid: true, WPSState: WPSConfigured, APSetupLocked: true}
+
+	tests := []struct {
+		name string
+		ie   WPSIE
+		prev WPSIE
+		want WPSIE
+	}{
+		{"beacon keeps device of probe response", beacon, probe, WPSIE{WPSValid: true, WPSState: WPSConfigured, APSetupLocked: true,
+			DeviceName: "Printer", ModelName: "LaserJet", ConfigMethods: 0x0080}},
+		{"absent element keeps previous one", WPSIE{}, probe, probe},
+		{"new device replaces previous one", WPSIE{WPSValid: true, DeviceName: "Camera"}, probe,
+			WPSIE{WPSValid: true, DeviceName: "Camera", ModelName: "LaserJet", ConfigMethods: 0x0080}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ie.Merge(tt.prev); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ const (
 	WidthOperation80      ChannelWidthOperation = 1 // 80MHz
 	WidthOperation160     ChannelWidthOperation = 2 // 160MHz
 	WidthOperation80And80 ChannelWidthOperation = 3 // 80+80MHz
+	WidthOperation320     ChannelWidthOperation = 4 // 320MHz, EHT in 6GHz only
 )
 
 func (o ChannelWidthOperation) String() string {
@@ -15,6 +16,7 @@ func (o ChannelWidthOperation) String() string {
 		WidthOperation80:      "80",
 		WidthOperation160:     "160",
 		WidthOperation80And80: "80+80",
+		WidthOperation320:     "320",
 	}[o]
 }
 
@@ -25,16 +27,18 @@ func (o ChannelWidthOperation) Width() uint16 {
 		WidthOperation80:      80,
 		WidthOperation160:     160,
 		WidthOperation80And80: 160,
+		WidthOperation320:     320,
 	}[o]
 }
 
-// 0 - 20MHz or 40MHz; 1 - 80MHz; 2 - 160MHz; 3 - 80+80MHz; others - reserved.
+// 0 - 20MHz or 40MHz; 1 - 80MHz; 2 - 160MHz; 3 - 80+80MHz; 4 - 320MHz (EHT); others - reserved.
 func GetChannelWidthOperation(w uint8) ChannelWidthOperation {
 	knownOperations := []ChannelWidthOperation{
 		WidthOperation20Or40,
 		WidthOperation80,
 		WidthOperation160,
 		WidthOperation80And80,
+		WidthOperation320,
 	}
 
 	if int(w) >= len(knownOperations) {
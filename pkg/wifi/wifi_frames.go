@@ -3,6 +3,7 @@ package wifi
 import (
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/google/gopacket/layers"
 )
@@ -26,6 +27,7 @@ func (f *RadioFrame) String() string {
 type Dot11Frame struct {
 	RadioFrame
 
+	Timestamp          time.Time // Packet capture time
 	Dot11Type          layers.Dot11Type
 	SourceAddress      net.HardwareAddr
 	DestinationAddress net.HardwareAddr
@@ -86,32 +88,188 @@ type DSSetIE struct {
 	Channel uint8
 }
 
+// Supported Rates and Extended Supported Rates Information Elements (tags).
+type RatesIE struct {
+	Rates []uint8 // 500 kbps units, MSB marks basic rate
+}
+
+// High Throughput Capabilities Information Element (tag).
+type HTCapabilitiesIE struct {
+	HTSupported bool   // element is present (802.11n)
+	HTInfo      uint16 // HT Capabilities Info
+	HTStreams   uint8  // spatial streams supported by Rx MCS set
+}
+
+// Very High Throughput Capabilities Information Element (tag).
+type VHTCapabilitiesIE struct {
+	VHTSupported bool   // element is present (802.11ac)
+	VHTInfo      uint32 // VHT Capabilities Info
+	VHTStreams   uint8  // spatial streams supported by Rx MCS map
+}
+
+// High Efficiency Capabilities and Operation Element extensions (tags).
+type HEIE struct {
+	HESupported bool   // capabilities element is present (802.11ax)
+	HEOperation uint32 // HE Operation Parameters
+	BSSColor    uint8  // BSS Color
+	HE6GHzOperationIE
+}
+
+// 6 GHz Operation Information of HE Operation Element extension.
+type HE6GHzOperationIE struct {
+	HE6GHzInfoValid       bool  // 6GHz Operation Information is present
+	HE6GHzPrimaryChannel  uint8 // primary channel
+	HE6GHzChannelWidth    uint8 // 0 - 20MHz; 1 - 40MHz; 2 - 80MHz; 3 - 160MHz or 80+80MHz
+	HE6GHzChannelCenter0  uint8 // center of 20/40/80MHz channel or primary 80MHz of 160MHz channel
+	HE6GHzChannelCenter1  uint8 // center of 160MHz channel or secondary 80MHz of 80+80MHz channel
+	HE6GHzDuplicateBeacon bool  // beacons are duplicated in non-HT format across 20MHz channels
+}
+
+// Extremely High Throughput Capabilities and Operation Element extensions (tags).
+type EHTIE struct {
+	EHTSupported          bool  // capabilities element is present (802.11be)
+	EHTChannelWidth       uint8 // 0 - 20MHz; 1 - 40MHz; 2 - 80MHz; 3 - 160MHz; 4 - 320MHz
+	EHTChannelCenterSeg0  uint8 // center of 80MHz channel segment containing primary channel
+	EHTChannelCenterSeg1  uint8 // center of 160MHz or 320MHz channel
+	EHTOperationInfoValid bool  // channel information is present in EHT Operation
+}
+
+// BSS Load Information Element (tag).
+type BSSLoadIE struct {
+	BSSLoadValid       bool   // element is present
+	StationCount       uint16 // number of stations associated with BSS
+	ChannelUtilization uint8  // percentage of time medium is busy, scaled to 255
+	AdmissionCapacity  uint16 // remaining medium time for explicit admission control, 32us/s units
+}
+
+// Returns channel utilization, %.
+func (ie *BSSLoadIE) Utilization() uint8 {
+	const maxUtilization = 255
+	return uint8(uint16(ie.ChannelUtilization) * 100 / maxUtilization) //nolint:gomnd // ignore
+}
+
+// Country Information Element (tag).
+type CountryIE struct {
+	Country     string           // ISO 3166-1 alpha-2 code, empty if element is absent
+	Environment byte             // ' ' - any; 'I' - indoor; 'O' - outdoor; 'X' - noncountry entity
+	Triplets    []CountryTriplet // subband triplets preceding operating triplets, i.e. of 2.4GHz and 5GHz bands
+}
+
+// Subband triplet of Country element: range of channels and maximum transmit power.
+type CountryTriplet struct {
+	FirstChannel uint8
+	NumChannels  uint8
+	MaxPower     int8 // dBm
+}
+
+// Wi-Fi Protected Setup vendor Information Element (tag).
+// Beacons carry state only, probe responses describe device as well.
+type WPSIE struct {
+	WPSValid      bool             // element is present
+	WPSState      WPSState         // configuration state of AP
+	APSetupLocked bool             // AP refuses external registrars, e.g. after PIN brute force
+	DeviceName    string           // user friendly device name
+	Manufacturer  string           // device manufacturer
+	ModelName     string           // device model name
+	ModelNumber   string           // device model number
+	SerialNumber  string           // device serial number
+	ConfigMethods WPSConfigMethods // configuration methods supported by device
+}
+
+// Wi-Fi Direct (P2P) vendor Information Element (tag).
+type P2PIE struct {
+	P2P           bool // element is present, BSS is Wi-Fi Direct group
+	P2PGroupOwner bool // transmitter is group owner
+}
+
+// Extended Capabilities Information Element (tag).
+type ExtCapabilitiesIE struct {
+	UTF8SSID bool // SSID is UTF-8 encoded
+}
+
 type SSIDIE struct {
 	SSID string
 }
 
+// Robust Security Network Information Element (tag).
+// Microsoft WPA vendor Information Element has the same layout without RSN capabilities.
+type RSNIE struct {
+	Version         uint16 // 1 if element is present
+	GroupCipher     CipherSuite
+	PairwiseCiphers []CipherSuite
+	AKMSuites       []AKMSuite
+	MFPRequired     bool // Management Frame Protection required
+	MFPCapable      bool // Management Frame Protection capable
+}
+
+// Security Information Elements (tags).
+type SecurityIE struct {
+	RSN RSNIE // WPA2/WPA3
+	WPA RSNIE // WPA (Microsoft vendor specific)
+}
+
 type InformationElements struct {
-	HTOperationIE  // optional
-	VHTOperationIE // optional
-	DSSetIE        // optional
+	HTOperationIE     // optional
+	VHTOperationIE    // optional
+	DSSetIE           // optional
+	SecurityIE        // optional
+	RatesIE           // optional
+	HTCapabilitiesIE  // optional
+	VHTCapabilitiesIE // optional
+	HEIE              // optional
+	EHTIE             // optional
+	BSSLoadIE         // optional
+	CountryIE         // optional
+	ExtCapabilitiesIE // optional
+	WPSIE             // optional
+	P2PIE             // optional
 	// SSIDIE         // optional
+
+	wpsAttrs []byte // attributes of WPS elements discovered so far, fragmented element continues in the next one
 }
 
 func (ie *InformationElements) String() string {
 	// return fmt.Sprintf("HT:%+v DS:%+v SSID:%+v", ie.HTOperationsIE, ie.DSSetIE, ie.SSIDIE)
-	return fmt.Sprintf("HT:%+v VHT:%+v DS:%+v", ie.HTOperationIE, ie.VHTOperationIE, ie.DSSetIE)
+	return fmt.Sprintf("HT:%+v VHT:%+v DS:%+v Security:%+v Rates:%v HTCap:%+v VHTCap:%+v HE:%+v EHT:%+v BSSLoad:%+v Country:%+v ExtCap:%+v WPS:%+v P2P:%+v",
+		ie.HTOperationIE, ie.VHTOperationIE, ie.DSSetIE, ie.SecurityIE,
+		ie.Rates, ie.HTCapabilitiesIE, ie.VHTCapabilitiesIE, ie.HEIE, ie.EHTIE, ie.BSSLoadIE, ie.CountryIE, ie.ExtCapabilitiesIE, ie.WPSIE, ie.P2PIE)
 }
 
 // Management frame.
 type MgmtFrame struct {
 	Dot11Frame
 	InformationElements
-	SSID string // optional
+	CapabilityInfo CapabilityInfo
+	SSID           SSID   // optional
+	HiddenSSID     bool   // SSID element is empty or filled with NUL bytes
+	rawSSID        []byte // SSID element octets, decoded once Extended Capabilities are discovered
 }
 
 func (f *MgmtFrame) String() string {
-	return fmt.Sprintf("Dot11:%+v, SSID:%s IE:%+v", f.Dot11Frame, f.SSID, f.InformationElements)
+	return fmt.Sprintf("Dot11:%+v, SSID:%s Hidden:%t Capabilities:%#04x IE:%+v",
+		f.Dot11Frame, f.SSID, f.HiddenSSID, uint16(f.CapabilityInfo), f.InformationElements)
 }
 
 // Generic frame.
 type Frame MgmtFrame
+
+// Returns true if management frame of given type carries SSID element.
+// Association responses identify network by BSSID only.
+func CarriesSSID(t layers.Dot11Type) bool {
+	return t == layers.Dot11TypeMgmtBeacon || t == layers.Dot11TypeMgmtProbeResp
+}
+
+// Frame transmitted by or to a client station.
+// Discovered from probe requests, association requests and data frames.
+type ClientFrame struct {
+	Dot11Frame
+	Station         net.HardwareAddr // client MAC address
+	FromClient      bool             // transmitted by client, so radio values are client's ones
+	ProbedSSID      string           // SSID of directed probe request, optional
+	AssociationSSID string           // SSID of (re)association request, reveals name of hidden network, optional
+}
+
+func (f *ClientFrame) String() string {
+	return fmt.Sprintf("Dot11:%+v, Station:%s FromClient:%t Probed:%s Association:%s",
+		f.Dot11Frame, f.Station, f.FromClient, f.ProbedSSID, f.AssociationSSID)
+}
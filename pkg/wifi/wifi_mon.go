@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sync/atomic"
 	"time"
 	log "wfmon/pkg/logger"
 	"wfmon/pkg/network"
@@ -18,26 +19,79 @@ const (
 	defaultFramesBuffer = 100
 )
 
+const (
+	// Keeps only frames discovered by monitor: beacons, probe and (re)association responses of access points,
+	// probe and (re)association requests and data frames of client stations.
+	// Association requests reveal names of hidden networks.
+	DefaultFilter = "type mgt and (subtype beacon or subtype probe-resp or subtype assoc-resp or subtype reassoc-resp" +
+		" or subtype probe-req or subtype assoc-req or subtype reassoc-req) or type data"
+	// Disables BPF filter, all packets are captured.
+	NoFilter = "none"
+)
+
 type Monitor struct {
 	ctx  context.Context
 	stop context.CancelFunc
 
-	iface    *net.Interface
-	file     string
-	handle   *pcap.Handle
-	framesCh chan Frame
+	iface     *net.Interface
+	file      string
+	handle    *pcap.Handle
+	framesCh  chan Frame
+	clientsCh chan ClientFrame
+	replay    *Replay // created once file is opened
+	speed     ReplaySpeed
+	filter    string
+
+	record         network.RecorderConfig
+	recordMgmtOnly bool
+	recorder       *network.Recorder
+
+	packetsRead   atomic.Uint64
+	framesDecoded atomic.Uint64
+}
+
+// Counters of processed packets.
+type MonitorStats struct {
+	PacketsRead   uint64 // packets read from interface or file
+	FramesDecoded uint64 // management and client frames decoded from packets
+	FramesDropped uint64 // packets dropped by capture before reading, always zero for file
 }
 
 type Config struct {
-	IFace *net.Interface
-	File  string
+	IFace       *net.Interface
+	File        string
+	ReplaySpeed ReplaySpeed // pcap file replay speed, zero for max
+	Filter      string      // BPF filter, @DefaultFilter if empty, @NoFilter to capture all packets
+
+	Record         network.RecorderConfig // live capture recording to pcapng files, disabled if path is empty
+	RecordMgmtOnly bool                   // record only discovered management frames instead of every packet
 }
 
 func NewMonitor(cfg *Config) *Monitor {
-	return &Monitor{
-		iface:    cfg.IFace,
-		file:     cfg.File,
-		framesCh: make(chan Frame, defaultFramesBuffer),
+	mon := &Monitor{
+		iface:     cfg.IFace,
+		file:      cfg.File,
+		framesCh:  make(chan Frame, defaultFramesBuffer),
+		clientsCh: make(chan ClientFrame, defaultFramesBuffer),
+		speed:     cfg.ReplaySpeed,
+		filter:    cfg.BPFFilter(),
+
+		record:         cfg.Record,
+		recordMgmtOnly: cfg.RecordMgmtOnly,
+	}
+
+	return mon
+}
+
+// Returns BPF filter expression for capture options, empty for no filter.
+func (cfg *Config) BPFFilter() string {
+	switch cfg.Filter {
+	case "":
+		return DefaultFilter
+	case NoFilter:
+		return ""
+	default:
+		return cfg.Filter
 	}
 }
 
@@ -48,13 +102,18 @@ func (mon *Monitor) Configure() error {
 	if mon.isFromFile() {
 		log.Infof("pcap file provided %s", mon.file)
 
-		if mon.handle, err = network.CaptureFromFile(mon.file); err == nil {
-			// loaded from file
+		if mon.handle, err = network.CaptureFromFileWithFilter(mon.file, mon.filter); err == nil {
+			// loaded from file, live capture falling back to interface is not paced
+			mon.replay = NewReplay(mon.speed)
 			return nil
 		} else if !mon.isFromIFace() {
 			// failed to load from file and iface not provided
 			return err
 		}
+
+		// capture from interface instead of file
+		log.Warnf("%s, falling back to interface", err)
+		mon.file = ""
 	}
 
 	if !mon.isFromIFace() {
@@ -68,11 +127,24 @@ func (mon *Monitor) Configure() error {
 		return err
 	}
 
-	log.Debugf("activate monitor on %s", mon.iface.Name)
-	if mon.handle, err = network.CaptureWithTimeout(mon.iface.Name, defaultTimeout); err != nil {
+	captureIface, err := radionet.CreateMonitorInterface(mon.iface.Name)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("activate monitor on %s", captureIface)
+	opts := network.DefaultOptions()
+	opts.Timeout = defaultTimeout
+	opts.Filter = mon.filter
+	if mon.handle, err = network.CaptureWithOptions(captureIface, opts); err != nil {
 		return err
 	}
 
+	if mon.record.Enabled() {
+		log.Infof("recording packets from %s to %s", mon.iface.Name, mon.record.Path)
+		mon.recorder = network.NewRecorder(mon.record, mon.handle.LinkType())
+	}
+
 	return nil
 }
 
@@ -82,6 +154,13 @@ func (mon *Monitor) Close() {
 		log.Info("closing pcap handle")
 		mon.handle.Close()
 	}
+
+	if mon.recorder != nil {
+		log.Info("closing packets recorder")
+		if err := mon.recorder.Close(); err != nil {
+			log.Error(err)
+		}
+	}
 }
 
 // Starts sniffering packets until shutdown.
@@ -109,21 +188,47 @@ func (mon *Monitor) Start(ctx context.Context) error {
 			// channel is closed or packets from file is over
 			if !ok {
 				if mon.isFromFile() {
+					log.Infof("packets from file %s are over", mon.file)
+					// client frames are drained when frames channel is closed
+					close(mon.clientsCh)
+					close(mon.framesCh)
 					<-mon.ctx.Done()
 					return nil
 				}
 				return fmt.Errorf("packet source closed, stopping monitoring")
 			}
 
+			// pace packets from file by capture time
+			if mon.replay != nil {
+				if err := mon.replay.Wait(mon.ctx, packet.Metadata().Timestamp); err != nil {
+					continue
+				}
+			}
+
+			mon.packetsRead.Add(1)
+
 			p := FromPacket(packet)
 			frame := p.DiscoverMgmtFrame()
+
+			if mon.recorder != nil && (!mon.recordMgmtOnly || frame != nil) {
+				if err := mon.recorder.Write(packet.Metadata().CaptureInfo, packet.Data()); err != nil {
+					log.Warn(err)
+				}
+			}
 			if frame != nil {
+				mon.framesDecoded.Add(1)
 				log.Debugf("%+v", frame)
 				// send a copy of frame to output channel
 				// if len(frame.SSID) > 0 {
 				mon.framesCh <- Frame(*frame)
 				// }
 			}
+			if frame == nil {
+				if client := p.DiscoverClientFrame(); client != nil {
+					mon.framesDecoded.Add(1)
+					mon.clientsCh <- *client
+				}
+			}
 
 		case <-mon.ctx.Done():
 			if mon.isFromIFace() {
@@ -144,11 +249,38 @@ func (mon *Monitor) Stop() error {
 	return nil
 }
 
+// Returns replay controls for packets from file, nil for interface or until file is opened by @Configure.
+func (mon *Monitor) Replay() *Replay {
+	return mon.replay
+}
+
+// Returns counters of processed packets.
+// Dropped packets are reported by live capture handle.
+func (mon *Monitor) Stats() MonitorStats {
+	stats := MonitorStats{
+		PacketsRead:   mon.packetsRead.Load(),
+		FramesDecoded: mon.framesDecoded.Load(),
+	}
+
+	if mon.isFromIFace() && !mon.isFromFile() && mon.handle != nil {
+		if captureStats, err := mon.handle.Stats(); err == nil {
+			stats.FramesDropped = uint64(captureStats.PacketsDropped + captureStats.PacketsIfDropped)
+		}
+	}
+
+	return stats
+}
+
 // Returns frames output channel.
 func (mon *Monitor) GetFrames() <-chan Frame {
 	return mon.framesCh
 }
 
+// Returns client frames output channel.
+func (mon *Monitor) GetClientFrames() <-chan ClientFrame {
+	return mon.clientsCh
+}
+
 func (mon *Monitor) isFromFile() bool {
 	return len(mon.file) > 0
 }